@@ -0,0 +1,42 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+	"user-service/common/requestid"
+	"user-service/constants"
+)
+
+type FileNotifier struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileNotifier appends every message to the file at path, which is useful
+// for inspecting emails in development and end-to-end tests.
+func NewFileNotifier(path string) (INotifier, error) {
+	if path == "" {
+		return nil, errors.New("notifier filePath is required for the file driver")
+	}
+	return &FileNotifier{path: path}, nil
+}
+
+func (n *FileNotifier) Send(ctx context.Context, message *Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	file, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = fmt.Fprintf(file, "Date: %s\nTo: %s\nSubject: %s\n%s: %s\n\n%s\n\n",
+		time.Now().Format(time.RFC1123Z), message.To, message.Subject,
+		constants.XRequestID, requestid.FromContext(ctx), message.Body)
+	return err
+}
@@ -0,0 +1,22 @@
+package notifier
+
+import (
+	"context"
+	"user-service/common/logger"
+
+	"github.com/sirupsen/logrus"
+)
+
+type LogNotifier struct{}
+
+func NewLogNotifier() INotifier {
+	return &LogNotifier{}
+}
+
+func (n *LogNotifier) Send(ctx context.Context, message *Message) error {
+	logger.FromContext(ctx).WithFields(logrus.Fields{
+		"to":      message.To,
+		"subject": message.Subject,
+	}).Info(message.Body)
+	return nil
+}
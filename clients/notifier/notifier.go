@@ -0,0 +1,42 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"user-service/config"
+)
+
+const (
+	DriverLog  = "log"
+	DriverFile = "file"
+	DriverSMTP = "smtp"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type INotifier interface {
+	Send(context.Context, *Message) error
+}
+
+// NewNotifier builds the notifier selected by cfg.Driver. The log driver is
+// the default in development. Anywhere else it is refused, since it delivers
+// nothing and the redacted log lines do not carry usable links.
+func NewNotifier(cfg config.Notifier) (INotifier, error) {
+	switch cfg.Driver {
+	case DriverLog, "":
+		if !config.IsDevelopment() {
+			return nil, fmt.Errorf("notifier driver %q is only allowed in development, set notifier.driver to %q or %q", cfg.Driver, DriverSMTP, DriverFile)
+		}
+		return NewLogNotifier(), nil
+	case DriverFile:
+		return NewFileNotifier(cfg.FilePath)
+	case DriverSMTP:
+		return NewSMTPNotifier(cfg.SMTP), nil
+	default:
+		return nil, fmt.Errorf("unknown notifier driver %q", cfg.Driver)
+	}
+}
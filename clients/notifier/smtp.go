@@ -0,0 +1,116 @@
+package notifier
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+	"user-service/common/requestid"
+	"user-service/config"
+	"user-service/constants"
+)
+
+type SMTPNotifier struct {
+	cfg config.SMTP
+}
+
+func NewSMTPNotifier(cfg config.SMTP) INotifier {
+	return &SMTPNotifier{cfg: cfg}
+}
+
+func (n *SMTPNotifier) Send(ctx context.Context, message *Message) error {
+	var auth smtp.Auth
+	if n.cfg.Username != "" {
+		auth = smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, n.cfg.Host)
+	}
+
+	headers := []string{
+		fmt.Sprintf("From: %s", n.cfg.From),
+		fmt.Sprintf("To: %s", message.To),
+		fmt.Sprintf("Subject: %s", message.Subject),
+		fmt.Sprintf("Date: %s", time.Now().Format(time.RFC1123Z)),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+	}
+	if id := requestid.FromContext(ctx); id != "" {
+		headers = append(headers, fmt.Sprintf("%s: %s", constants.XRequestID, id))
+	}
+	body := strings.Join(headers, "\r\n") + "\r\n\r\n" + message.Body
+
+	addr := fmt.Sprintf("%s:%d", n.cfg.Host, n.cfg.Port)
+	err := n.sendMail(ctx, addr, auth, message.To, []byte(body))
+	if err != nil && ctx.Err() != nil {
+		return fmt.Errorf("smtp: %w", ctx.Err())
+	}
+	return err
+}
+
+// sendMail does what smtp.SendMail does, but dials with ctx and bounds the
+// whole conversation by its deadline, so a slow or hung server cannot hold the
+// caller past it. Cancelling ctx closes the connection.
+func (n *SMTPNotifier) sendMail(ctx context.Context, addr string, auth smtp.Auth, to string, body []byte) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		err = conn.SetDeadline(deadline)
+		if err != nil {
+			return err
+		}
+	}
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	client, err := smtp.NewClient(conn, n.cfg.Host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		err = client.StartTLS(&tls.Config{ServerName: n.cfg.Host})
+		if err != nil {
+			return err
+		}
+	}
+	if auth != nil {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return errors.New("smtp: server doesn't support AUTH")
+		}
+		err = client.Auth(auth)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = client.Mail(n.cfg.From)
+	if err != nil {
+		return err
+	}
+	err = client.Rcpt(to)
+	if err != nil {
+		return err
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(body)
+	if err != nil {
+		return err
+	}
+	err = writer.Close()
+	if err != nil {
+		return err
+	}
+	return client.Quit()
+}
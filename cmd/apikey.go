@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+	"user-service/common/apikey"
+	"user-service/domain/dto"
+	"user-service/repositories"
+	apiKeyServices "user-service/services/apikey"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/spf13/cobra"
+)
+
+var apiKeyCommand = &cobra.Command{
+	Use:   "apikey",
+	Short: "manage service-to-service API credentials",
+}
+
+func newAPIKeyService() (apiKeyServices.IAPIKeyService, error) {
+	db, err := initDatabase()
+	if err != nil {
+		return nil, err
+	}
+	return apiKeyServices.NewAPIKeyService(repositories.NewRepositoryRegistry(db)), nil
+}
+
+func printServiceClientSecret(client *dto.ServiceClientSecretResponse) {
+	fmt.Printf("service client %s (%s)\n", client.Name, client.UUID)
+	if client.PreviousSecretExpiresAt != nil {
+		fmt.Println("previous secret valid until:", client.PreviousSecretExpiresAt.Format(time.RFC3339))
+	}
+	fmt.Println("secret:", client.Secret)
+}
+
+var apiKeyIssueCommand = &cobra.Command{
+	Use:   "issue",
+	Short: "register a service client and print its secret once",
+	Args:  cobra.NoArgs,
+	RunE: func(c *cobra.Command, args []string) error {
+		request := &dto.ServiceClientRequest{}
+		request.Name, _ = c.Flags().GetString("service")
+		request.Scopes, _ = c.Flags().GetStringArray("scope")
+		err := validator.New().Struct(request)
+		if err != nil {
+			return err
+		}
+
+		service, err := newAPIKeyService()
+		if err != nil {
+			return err
+		}
+		client, err := service.IssueClient(c.Context(), request)
+		if err != nil {
+			return err
+		}
+		printServiceClientSecret(client)
+		return nil
+	},
+}
+
+var apiKeyRotateCommand = &cobra.Command{
+	Use:   "rotate",
+	Short: "replace a service client's secret, keeping the old one valid for --overlap",
+	Args:  cobra.NoArgs,
+	RunE: func(c *cobra.Command, args []string) error {
+		name, _ := c.Flags().GetString("service")
+		request := &dto.RotateServiceClientRequest{}
+		if c.Flags().Changed("overlap") {
+			overlap, _ := c.Flags().GetDuration("overlap")
+			minutes := int(overlap / time.Minute)
+			request.OverlapMinutes = &minutes
+		}
+		err := validator.New().Struct(request)
+		if err != nil {
+			return err
+		}
+
+		service, err := newAPIKeyService()
+		if err != nil {
+			return err
+		}
+		client, err := service.RotateClient(c.Context(), name, request)
+		if err != nil {
+			return err
+		}
+		printServiceClientSecret(client)
+		return nil
+	},
+}
+
+var apiKeyRevokeCommand = &cobra.Command{
+	Use:   "revoke",
+	Short: "disable a service client",
+	Args:  cobra.NoArgs,
+	RunE: func(c *cobra.Command, args []string) error {
+		name, _ := c.Flags().GetString("service")
+		service, err := newAPIKeyService()
+		if err != nil {
+			return err
+		}
+		err = service.RevokeClient(c.Context(), name)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("service client %s revoked\n", name)
+		return nil
+	},
+}
+
+var apiKeyListCommand = &cobra.Command{
+	Use:   "list",
+	Short: "list service clients",
+	Args:  cobra.NoArgs,
+	RunE: func(c *cobra.Command, args []string) error {
+		service, err := newAPIKeyService()
+		if err != nil {
+			return err
+		}
+		clients, err := service.GetClients(c.Context())
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tENABLED\tSCOPES\tLAST USED")
+		for _, client := range clients {
+			lastUsed := ""
+			if client.LastUsedAt != nil {
+				lastUsed = client.LastUsedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(w, "%s\t%t\t%v\t%s\n", client.Name, client.Enabled, client.Scopes, lastUsed)
+		}
+		return w.Flush()
+	},
+}
+
+var apiKeyGenerateCommand = &cobra.Command{
+	Use:   "generate",
+	Short: "print the headers a service needs to make one request to this one",
+	Args:  cobra.NoArgs,
+	RunE: func(c *cobra.Command, args []string) error {
+		serviceName, _ := c.Flags().GetString("service")
+		method, _ := c.Flags().GetString("method")
+		path, _ := c.Flags().GetString("path")
+		body, _ := c.Flags().GetString("body")
+
+		service, err := newAPIKeyService()
+		if err != nil {
+			return err
+		}
+		secret, err := service.ClientSecret(c.Context(), serviceName)
+		if err != nil {
+			return err
+		}
+
+		nonce, err := apikey.NewNonce()
+		if err != nil {
+			return err
+		}
+		request := &apikey.Request{
+			ServiceName: serviceName,
+			Method:      method,
+			Path:        path,
+			RequestAt:   strconv.FormatInt(time.Now().Unix(), 10),
+			BodyHash:    apikey.HashBody([]byte(body)),
+			Nonce:       nonce,
+		}
+
+		fmt.Println("x-service-name:", request.ServiceName)
+		fmt.Println("x-request-at:", request.RequestAt)
+		fmt.Println("x-nonce:", request.Nonce)
+		fmt.Println("x-api-key:", apikey.Sign(secret, request))
+		return nil
+	},
+}
+
+func init() {
+	for _, command := range []*cobra.Command{apiKeyIssueCommand, apiKeyRotateCommand, apiKeyRevokeCommand, apiKeyGenerateCommand} {
+		command.Flags().String("service", "", "name of the calling service")
+		_ = command.MarkFlagRequired("service")
+	}
+	apiKeyIssueCommand.Flags().StringArray("scope", nil, `allowed route as "METHOD /api/v1/route", or "*" for all (repeatable)`)
+	_ = apiKeyIssueCommand.MarkFlagRequired("scope")
+	apiKeyRotateCommand.Flags().Duration("overlap", 0, "how long the old secret stays valid (default from config)")
+	apiKeyGenerateCommand.Flags().String("method", "GET", "HTTP method of the request")
+	apiKeyGenerateCommand.Flags().String("path", "", "request path including the query string, e.g. /api/v1/auth/user")
+	apiKeyGenerateCommand.Flags().String("body", "", "exact request body")
+	_ = apiKeyGenerateCommand.MarkFlagRequired("path")
+	apiKeyCommand.AddCommand(apiKeyIssueCommand, apiKeyRotateCommand, apiKeyRevokeCommand, apiKeyListCommand, apiKeyGenerateCommand)
+}
@@ -1,15 +1,21 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
+	"user-service/clients/notifier"
+	"user-service/common/health"
+	"user-service/common/jwk"
+	"user-service/common/requestid"
 	"user-service/common/response"
+	"user-service/common/tracing"
 	"user-service/config"
 	"user-service/constants"
 	"user-service/controllers"
+	"user-service/database/migrations"
 	"user-service/database/seeders"
-	"user-service/domain/models"
 	"user-service/middlewares"
 	"user-service/repositories"
 	"user-service/routes"
@@ -18,63 +24,133 @@ import (
 	"github.com/didip/tollbooth"
 	"github.com/didip/tollbooth/limiter"
 	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
-var command = &cobra.Command{
+const defaultHealthCheckTimeoutSeconds = 2
+
+var serveCommand = &cobra.Command{
 	Use:   "serve",
 	Short: "start the server",
 	Run: func(c *cobra.Command, args []string) {
-		_ = godotenv.Load()
 		// Load the environment variables from the .env file
 		// and start the server
-		config.Init()
-		db, err := config.InitDatabase()
+		db, err := initDatabase()
 		if err != nil {
 			panic(err)
 		}
+		jwk.Init()
 
-		loc, err := time.LoadLocation("Asia/Jakarta")
+		shutdownTracing, err := tracing.Init(c.Context())
+		if err != nil {
+			panic(err)
+		}
+		defer func() {
+			err := shutdownTracing(context.Background())
+			if err != nil {
+				logrus.Errorf("failed to flush traces: %v", err)
+			}
+		}()
+		err = db.Use(tracing.NewGormPlugin())
 		if err != nil {
 			panic(err)
 		}
 
-		time.Local = loc
-
-		err = db.AutoMigrate(
-			&models.Role{},
-			&models.User{},
-		)
+		migrator, err := migrations.NewMigrator(db)
+		if err != nil {
+			panic(err)
+		}
+		migrate, _ := c.Flags().GetBool("migrate")
+		if migrate {
+			_, err = migrator.Up(c.Context())
+			if err != nil {
+				panic(err)
+			}
+		}
+		pending, err := migrator.Pending(c.Context())
 		if err != nil {
 			panic(err)
 		}
+		if len(pending) > 0 {
+			logrus.Warnf("%d database migration(s) are pending, run `user-service migrate up`", len(pending))
+		}
 
-		seeders.NewSeederRegistry(db).Run()
+		seed, _ := c.Flags().GetBool("seed")
+		if seed {
+			seeders.NewSeederRegistry(db).Run()
+		}
 		repository := repositories.NewRepositoryRegistry(db)
-		service := services.NewServiceRegistry(repository)
+		notify, err := notifier.NewNotifier(config.Config.Notifier)
+		if err != nil {
+			panic(err)
+		}
+
+		service := services.NewServiceRegistry(repository, notify)
+		startPurges(c.Context(), service)
 		controller := controllers.NewControllerRegistry(service)
 
-		router := gin.Default()
-		router.Use(middlewares.HandlePanic())
+		if !config.IsDevelopment() {
+			gin.SetMode(gin.ReleaseMode)
+		}
+		router := gin.New()
+		// Client IPs feed the login throttle and the audit log, so
+		// X-Forwarded-For is only honoured from the configured proxies.
+		err = router.SetTrustedProxies(config.Config.TrustedProxies)
+		if err != nil {
+			panic(err)
+		}
+		router.Use(
+			otelgin.Middleware(config.Config.AppName),
+			middlewares.RequestID(),
+			middlewares.RequestLogger(),
+			middlewares.Metrics(),
+			middlewares.HandlePanic(),
+		)
+		router.Use(func(c *gin.Context) {
+			c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+			c.Writer.Header().Set("Access-COntrol-Allow_Methods", "GET, POST, PUT")
+			c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, x-service-name, x-api-key, x-request-at, x-nonce, x-request-id")
+			c.Writer.Header().Set("Access-Control-Expose-Headers", "x-request-id")
+			c.Next()
+		})
 		router.NoRoute(func(c *gin.Context) {
 			c.JSON(http.StatusNotFound, response.Response{
-				Status:  constants.Error,
-				Message: fmt.Sprintf("Path %s", http.StatusText(http.StatusNotFound)),
+				Status:    constants.Error,
+				Message:   fmt.Sprintf("Path %s", http.StatusText(http.StatusNotFound)),
+				RequestID: requestid.FromContext(c.Request.Context()),
 			})
 		})
 		router.GET("/", func(c *gin.Context) {
 			c.JSON(http.StatusOK, response.Response{
-				Status:  constants.Success,
-				Message: "Welcome to User Service",
+				Status:    constants.Success,
+				Message:   "Welcome to User Service",
+				RequestID: requestid.FromContext(c.Request.Context()),
 			})
 		})
-		router.Use(func(c *gin.Context) {
-			c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
-			c.Writer.Header().Set("Access-COntrol-Allow_Methods", "GET, POST, PUT")
-			c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, x-service-name, x-api-key, x-request-id")
-			c.Next()
+		router.GET("/.well-known/jwks.json", func(c *gin.Context) {
+			c.Header("Cache-Control", "public, max-age=300")
+			c.JSON(http.StatusOK, jwk.JWKS())
 		})
+		router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+		sqlDB, err := db.DB()
+		if err != nil {
+			panic(err)
+		}
+		healthTimeout := config.Config.HealthCheckTimeoutSeconds
+		if healthTimeout <= 0 {
+			healthTimeout = defaultHealthCheckTimeoutSeconds
+		}
+		router.GET("/healthz", health.Liveness())
+		router.GET("/readyz", health.Readiness(
+			time.Duration(healthTimeout)*time.Second,
+			health.Database(sqlDB),
+			health.Migrations(migrator),
+			health.Config(),
+		))
 
 		if config.Config.EnableRateLimiter {
 			lmt := tollbooth.NewLimiter(
@@ -87,7 +163,7 @@ var command = &cobra.Command{
 		}
 
 		group := router.Group("/api/v1")
-		route := routes.NewRouteRegsitry(controller, group)
+		route := routes.NewRouteRegsitry(controller, service, group)
 		route.Serve()
 
 		port := fmt.Sprintf(":%d", config.Config.Port)
@@ -95,9 +171,7 @@ var command = &cobra.Command{
 	},
 }
 
-func Run() {
-	err := command.Execute()
-	if err != nil {
-		panic(err)
-	}
+func init() {
+	serveCommand.Flags().Bool("migrate", false, "apply pending database migrations before starting")
+	serveCommand.Flags().Bool("seed", false, "run all seeders before starting")
 }
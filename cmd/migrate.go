@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+	"user-service/database/migrations"
+
+	"github.com/spf13/cobra"
+)
+
+var migrateCommand = &cobra.Command{
+	Use:   "migrate",
+	Short: "manage database schema migrations",
+}
+
+var migrateUpCommand = &cobra.Command{
+	Use:   "up",
+	Short: "apply all pending migrations",
+	Args:  cobra.NoArgs,
+	RunE: func(c *cobra.Command, args []string) error {
+		migrator, err := newMigrator()
+		if err != nil {
+			return err
+		}
+
+		applied, err := migrator.Up(c.Context())
+		if err != nil {
+			return err
+		}
+		fmt.Printf("applied %d migration(s)\n", len(applied))
+		return nil
+	},
+}
+
+var migrateDownCommand = &cobra.Command{
+	Use:   "down",
+	Short: "roll back the latest migrations",
+	Args:  cobra.NoArgs,
+	RunE: func(c *cobra.Command, args []string) error {
+		steps, err := c.Flags().GetInt("steps")
+		if err != nil {
+			return err
+		}
+		if steps < 1 {
+			return fmt.Errorf("--steps must be at least 1")
+		}
+
+		migrator, err := newMigrator()
+		if err != nil {
+			return err
+		}
+
+		rolledBack, err := migrator.Down(c.Context(), steps)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("rolled back %d migration(s)\n", len(rolledBack))
+		return nil
+	},
+}
+
+var migrateStatusCommand = &cobra.Command{
+	Use:   "status",
+	Short: "list migrations and whether they are applied",
+	Args:  cobra.NoArgs,
+	RunE: func(c *cobra.Command, args []string) error {
+		migrator, err := newMigrator()
+		if err != nil {
+			return err
+		}
+
+		statuses, err := migrator.Status(c.Context())
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			if status.Unknown {
+				appliedAt += " (unknown to this build)"
+			}
+			fmt.Fprintf(w, "%06d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		return w.Flush()
+	},
+}
+
+var migrateCreateCommand = &cobra.Command{
+	Use:   "create <name>",
+	Short: "create empty up and down SQL files for a new migration",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(c *cobra.Command, args []string) error {
+		dir, err := c.Flags().GetString("dir")
+		if err != nil {
+			return err
+		}
+
+		files, err := migrations.Create(dir, args[0])
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			fmt.Println("created", file)
+		}
+		return nil
+	},
+}
+
+func init() {
+	migrateDownCommand.Flags().Int("steps", 1, "number of migrations to roll back")
+	migrateCreateCommand.Flags().String("dir", "database/migrations/sql", "directory of the SQL migrations")
+	migrateCommand.AddCommand(migrateUpCommand, migrateDownCommand, migrateStatusCommand, migrateCreateCommand)
+}
+
+func newMigrator() (*migrations.Migrator, error) {
+	db, err := initDatabase()
+	if err != nil {
+		return nil, err
+	}
+	return migrations.NewMigrator(db)
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"time"
+	"user-service/services"
+
+	"github.com/sirupsen/logrus"
+)
+
+const purgeInterval = 5 * time.Minute
+
+// startPurges deletes expired revoked tokens and API key nonces in the
+// background every purgeInterval until ctx is done, so that request handling
+// never waits on the deletes. Every replica runs it; the deletes are
+// idempotent.
+func startPurges(ctx context.Context, service services.IServiceRegistry) {
+	go func() {
+		ticker := time.NewTicker(purgeInterval)
+		defer ticker.Stop()
+
+		for {
+			purgeExpired(ctx, service)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func purgeExpired(ctx context.Context, service services.IServiceRegistry) {
+	tokens, err := service.GetRevocation().PurgeExpired(ctx)
+	if err != nil {
+		logrus.Errorf("failed to purge expired revoked tokens: %v", err)
+	} else if tokens > 0 {
+		logrus.Debugf("purged %d expired revoked token(s)", tokens)
+	}
+
+	nonces, err := service.GetAPIKey().PurgeExpiredNonces(ctx)
+	if err != nil {
+		logrus.Errorf("failed to purge expired api key nonces: %v", err)
+	} else if nonces > 0 {
+		logrus.Debugf("purged %d expired api key nonce(s)", nonces)
+	}
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"time"
+	"user-service/common/logger"
+	"user-service/config"
+
+	"github.com/joho/godotenv"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+var rootCommand = &cobra.Command{
+	Use:   "user-service",
+	Short: "user and auth service",
+}
+
+func init() {
+	rootCommand.AddCommand(serveCommand, migrateCommand, seedCommand, userCommand, apiKeyCommand)
+}
+
+// initDatabase loads the configuration and opens the database, which every
+// subcommand needs.
+func initDatabase() (*gorm.DB, error) {
+	_ = godotenv.Load()
+	config.Init()
+	logger.Init(config.Config.LogLevel)
+	db, err := config.InitDatabase()
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		return nil, err
+	}
+	time.Local = loc
+
+	return db, nil
+}
+
+func Run() {
+	err := rootCommand.Execute()
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"strings"
+	"user-service/database/seeders"
+
+	"github.com/spf13/cobra"
+)
+
+var seedCommand = &cobra.Command{
+	Use:   "seed",
+	Short: "populate the database with seed data",
+	Args:  cobra.NoArgs,
+	RunE: func(c *cobra.Command, args []string) error {
+		only, err := c.Flags().GetStringSlice("only")
+		if err != nil {
+			return err
+		}
+
+		db, err := initDatabase()
+		if err != nil {
+			return err
+		}
+
+		registry := seeders.NewSeederRegistry(db)
+		if len(only) == 0 {
+			registry.Run()
+			return nil
+		}
+		return registry.RunOnly(only...)
+	},
+}
+
+func init() {
+	seedCommand.Flags().StringSlice("only", nil, "run only these seeders ("+strings.Join(seeders.Names(), ", ")+")")
+}
@@ -0,0 +1,62 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"user-service/common/jwk"
+	"user-service/common/logger"
+	"user-service/common/secretbox"
+	"user-service/config"
+	"user-service/database/migrations"
+)
+
+// Database pings the connection pool. The driver error, which may name the
+// host, is only logged.
+func Database(db *sql.DB) Check {
+	return Check{
+		Name: "database",
+		Run: func(ctx context.Context) error {
+			err := db.PingContext(ctx)
+			if err != nil {
+				logger.FromContext(ctx).WithError(err).Warn("database ping failed")
+				return errors.New("database is unreachable")
+			}
+			return nil
+		},
+	}
+}
+
+// Migrations fails while the schema is behind this build.
+func Migrations(migrator *migrations.Migrator) Check {
+	return Check{
+		Name: "migrations",
+		Run: func(ctx context.Context) error {
+			pending, err := migrator.Pending(ctx)
+			if err != nil {
+				logger.FromContext(ctx).WithError(err).Warn("failed to read migration status")
+				return errors.New("migration status is unavailable")
+			}
+			if len(pending) > 0 {
+				return fmt.Errorf("%d migration(s) pending", len(pending))
+			}
+			return nil
+		},
+	}
+}
+
+// Config fails when a secret the service cannot work without is missing: the
+// encryption key for stored secrets and the JWT signing keys.
+func Config() Check {
+	return Check{
+		Name: "config",
+		Run: func(context.Context) error {
+			err := secretbox.CheckKey(config.Config.EncryptionKey)
+			if err != nil {
+				return err
+			}
+			return jwk.Ready()
+		},
+	}
+}
@@ -0,0 +1,255 @@
+package jwk
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"user-service/config"
+	errConstant "user-service/constants/error"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type Key struct {
+	ID      string
+	Method  jwt.SigningMethod
+	private crypto.Signer
+	public  crypto.PublicKey
+}
+
+// KeySet holds the keys used to sign and verify access tokens. When no
+// asymmetric keys are configured it falls back to HS256 with the shared
+// JwtSecretKey.
+type KeySet struct {
+	signing *Key
+	keys    map[string]*Key
+	secret  []byte
+}
+
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+var keySet = &KeySet{}
+
+func Init() {
+	set, err := Load(config.Config.JwtSigningKeyID, config.Config.JwtKeys, config.Config.JwtSecretKey)
+	if err != nil {
+		panic(err)
+	}
+	keySet = set
+}
+
+func Load(signingKeyID string, keys []config.JwtKey, secret string) (*KeySet, error) {
+	set := &KeySet{keys: map[string]*Key{}, secret: []byte(secret)}
+	for _, item := range keys {
+		key, err := loadKey(item)
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %q: %w", item.KeyID, err)
+		}
+		if _, exists := set.keys[key.ID]; exists {
+			return nil, fmt.Errorf("jwt key %q: duplicate key id", key.ID)
+		}
+		set.keys[key.ID] = key
+
+		if set.signing == nil && key.private != nil && (signingKeyID == "" || signingKeyID == key.ID) {
+			set.signing = key
+		}
+	}
+
+	if len(set.keys) > 0 && set.signing == nil {
+		return nil, fmt.Errorf("jwt signing key %q not found or has no private key", signingKeyID)
+	}
+	if len(set.keys) == 0 && len(set.secret) == 0 {
+		return nil, errors.New("no jwt keys or secret configured")
+	}
+	return set, nil
+}
+
+func loadKey(item config.JwtKey) (*Key, error) {
+	if item.KeyID == "" {
+		return nil, errors.New("keyId is required")
+	}
+
+	key := &Key{ID: item.KeyID}
+	switch {
+	case item.PrivateKeyPath != "":
+		block, err := readPEM(item.PrivateKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		private, err := parsePrivateKey(block)
+		if err != nil {
+			return nil, err
+		}
+		key.private = private
+		key.public = private.Public()
+	case item.PublicKeyPath != "":
+		block, err := readPEM(item.PublicKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		public, err := parsePublicKey(block)
+		if err != nil {
+			return nil, err
+		}
+		key.public = public
+	default:
+		return nil, errors.New("privateKeyPath or publicKeyPath is required")
+	}
+
+	switch key.public.(type) {
+	case *rsa.PublicKey:
+		key.Method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		key.Method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", key.public)
+	}
+	return key, nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM data found", path)
+	}
+	return block, nil
+}
+
+func parsePrivateKey(block *pem.Block) (crypto.Signer, error) {
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		return signer, nil
+	}
+	return x509.ParsePKCS1PrivateKey(block.Bytes)
+}
+
+func parsePublicKey(block *pem.Block) (crypto.PublicKey, error) {
+	if key, err := x509.ParsePKIXPublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return x509.ParsePKCS1PublicKey(block.Bytes)
+}
+
+// Ready fails until Init has loaded a signing key or the shared secret.
+func Ready() error {
+	if keySet.signing == nil && len(keySet.secret) == 0 {
+		return errors.New("jwt signing keys are not loaded")
+	}
+	return nil
+}
+
+func Sign(claims jwt.Claims) (string, error) {
+	return keySet.Sign(claims)
+}
+
+func Keyfunc(token *jwt.Token) (interface{}, error) {
+	return keySet.Keyfunc(token)
+}
+
+func ValidMethods() []string {
+	return keySet.ValidMethods()
+}
+
+func JWKS() JSONWebKeySet {
+	return keySet.JWKS()
+}
+
+func (k *KeySet) Sign(claims jwt.Claims) (string, error) {
+	if k.signing == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(k.secret)
+	}
+
+	token := jwt.NewWithClaims(k.signing.Method, claims)
+	token.Header["kid"] = k.signing.ID
+	return token.SignedString(k.signing.private)
+}
+
+// Keyfunc resolves the verification key from the token's kid header. Tokens
+// must use the algorithm of the key they name.
+func (k *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	if k.signing == nil {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errConstant.ErrInvalidToken
+		}
+		return k.secret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, ok := k.keys[kid]
+	if !ok || token.Method.Alg() != key.Method.Alg() {
+		return nil, errConstant.ErrInvalidToken
+	}
+	return key.public, nil
+}
+
+func (k *KeySet) ValidMethods() []string {
+	if k.signing == nil {
+		return []string{jwt.SigningMethodHS256.Alg()}
+	}
+
+	methods := []string{}
+	seen := map[string]bool{}
+	for _, key := range k.keys {
+		if !seen[key.Method.Alg()] {
+			seen[key.Method.Alg()] = true
+			methods = append(methods, key.Method.Alg())
+		}
+	}
+	return methods
+}
+
+// JWKS returns the public half of every configured key. The shared HS256
+// secret is never published.
+func (k *KeySet) JWKS() JSONWebKeySet {
+	ids := make([]string, 0, len(k.keys))
+	for id := range k.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	set := JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, id := range ids {
+		key := k.keys[id]
+		item := JSONWebKey{Kid: key.ID, Use: "sig", Alg: key.Method.Alg()}
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			item.Kty = "RSA"
+			item.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			item.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			item.Kty = "OKP"
+			item.Crv = "Ed25519"
+			item.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		set.Keys = append(set.Keys, item)
+	}
+	return set
+}
@@ -0,0 +1,58 @@
+package logger
+
+import (
+	"context"
+	"os"
+	"strings"
+	"user-service/constants"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Init sets up the standard logrus logger used across the service: JSON
+// output to stdout, the given level (info when empty or unknown) and the
+// redaction hook.
+func Init(level string) {
+	logrus.SetOutput(os.Stdout)
+	logrus.SetFormatter(&logrus.JSONFormatter{
+		FieldMap: logrus.FieldMap{
+			logrus.FieldKeyTime: "time",
+			logrus.FieldKeyMsg:  "message",
+		},
+	})
+
+	parsed, err := logrus.ParseLevel(strings.TrimSpace(level))
+	if err != nil {
+		parsed = logrus.InfoLevel
+	}
+	logrus.SetLevel(parsed)
+
+	logrus.StandardLogger().ReplaceHooks(make(logrus.LevelHooks))
+	logrus.AddHook(&redactHook{})
+
+	if err != nil && level != "" {
+		logrus.Warnf("unknown log level %q, using info", level)
+	}
+}
+
+// WithContext returns a context carrying the entry, so that everything logged
+// while handling a request shares its fields.
+func WithContext(ctx context.Context, entry *logrus.Entry) context.Context {
+	return context.WithValue(ctx, constants.ContextKeyLogger, entry)
+}
+
+// WithFields adds fields to the entry carried by the context.
+func WithFields(ctx context.Context, fields logrus.Fields) context.Context {
+	return WithContext(ctx, FromContext(ctx).WithFields(fields))
+}
+
+// FromContext returns the entry carried by the context, or one without fields
+// when there is none.
+func FromContext(ctx context.Context) *logrus.Entry {
+	if ctx != nil {
+		if entry, ok := ctx.Value(constants.ContextKeyLogger).(*logrus.Entry); ok {
+			return entry.WithContext(ctx)
+		}
+	}
+	return logrus.NewEntry(logrus.StandardLogger()).WithContext(ctx)
+}
@@ -0,0 +1,129 @@
+package logger
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const redacted = "[REDACTED]"
+
+// Field names whose values are never logged, matched as substrings of the
+// lower-cased name with '-' and '_' removed.
+var secretFields = []string{
+	"password",
+	"token",
+	"secret",
+	"apikey",
+	"authorization",
+	"signature",
+	"recoverycode",
+	"otp",
+}
+
+// Field names holding personal data, which are logged masked.
+var piiFields = []string{
+	"email",
+	"phone",
+}
+
+var (
+	bearerPattern     = regexp.MustCompile(`(?i)bearer\s+[^\s"',]+`)
+	jwtPattern        = regexp.MustCompile(`eyJ[\w-]+\.[\w-]+\.[\w-]*`)
+	assignmentPattern = regexp.MustCompile(`(?i)((?:password|secret|token|api[-_]?key|signature)\w*["']?\s*[:=]\s*["']?)[^\s"',&}]+`)
+	phonePattern      = regexp.MustCompile(`(?i)(phone\w*["']?\s*[:=]\s*["']?)[^\s"',&}]+`)
+	emailPattern      = regexp.MustCompile(`([\w.+-])[\w.+-]*@([\w-]+\.[\w.-]+)`)
+)
+
+type redactHook struct{}
+
+func (h *redactHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire rewrites the entry before it is formatted: values of sensitive fields
+// are replaced or masked, and tokens, keys and email addresses are scrubbed
+// from the message and from every other value.
+func (h *redactHook) Fire(entry *logrus.Entry) error {
+	entry.Message = Redact(entry.Message)
+
+	data := make(logrus.Fields, len(entry.Data))
+	for key, value := range entry.Data {
+		data[key] = redactField(key, value)
+	}
+	entry.Data = data
+	return nil
+}
+
+func fieldKind(key string) (secret bool, pii bool) {
+	normalized := strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(key))
+	for _, name := range secretFields {
+		if strings.Contains(normalized, name) {
+			return true, false
+		}
+	}
+	for _, name := range piiFields {
+		if strings.Contains(normalized, name) {
+			return false, true
+		}
+	}
+	return false, false
+}
+
+func redactField(key string, value any) any {
+	if key == logrus.ErrorKey {
+		if err, ok := value.(error); ok {
+			return Redact(err.Error())
+		}
+	}
+
+	secret, pii := fieldKind(key)
+	switch {
+	case secret:
+		return redacted
+	case pii:
+		if text, ok := value.(string); ok {
+			return mask(text)
+		}
+	}
+
+	switch v := value.(type) {
+	case nil, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return v
+	case string:
+		return Redact(v)
+	case error:
+		return Redact(v.Error())
+	case fmt.Stringer:
+		return Redact(v.String())
+	default:
+		return Redact(fmt.Sprintf("%+v", v))
+	}
+}
+
+// mask keeps the first character and, for email addresses, the domain.
+func mask(value string) string {
+	if value == "" {
+		return value
+	}
+	if local, domain, found := strings.Cut(value, "@"); found && local != "" {
+		return local[:1] + "***@" + domain
+	}
+	if len(value) <= 4 {
+		return "***"
+	}
+	return value[:1] + "***" + value[len(value)-2:]
+}
+
+// Redact scrubs bearer tokens, JWTs, key=value secrets, phone numbers and
+// email addresses from free text.
+func Redact(text string) string {
+	text = bearerPattern.ReplaceAllString(text, "Bearer "+redacted)
+	text = jwtPattern.ReplaceAllString(text, redacted)
+	text = assignmentPattern.ReplaceAllString(text, "${1}"+redacted)
+	text = phonePattern.ReplaceAllString(text, "${1}"+redacted)
+	text = emailPattern.ReplaceAllString(text, "${1}***@${2}")
+	return text
+}
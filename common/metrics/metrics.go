@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "user_service"
+
+// Outcomes and reasons used as label values. They are fixed sets so that the
+// number of series stays bounded.
+const (
+	LoginSuccess            = "success"
+	LoginMFARequired        = "mfa_required"
+	LoginInvalidCredentials = "invalid_credentials"
+	LoginInvalidMFA         = "invalid_mfa"
+	LoginLocked             = "locked"
+	LoginDenied             = "denied"
+	LoginError              = "error"
+
+	RegistrationSuccess = "success"
+	RegistrationExists  = "exists"
+	RegistrationInvalid = "invalid"
+	RegistrationError   = "error"
+
+	LimiterRequest       = "request"
+	LimiterLogin         = "login"
+	LimiterPasswordReset = "password_reset"
+
+	JWTMissing          = "missing"
+	JWTMalformed        = "malformed"
+	JWTExpired          = "expired"
+	JWTInvalidSignature = "invalid_signature"
+	JWTInvalid          = "invalid"
+	JWTRevoked          = "revoked"
+	JWTInactive         = "inactive"
+	JWTError            = "error"
+
+	APIKeyInvalid   = "invalid"
+	APIKeyExpired   = "expired"
+	APIKeyReplayed  = "replayed"
+	APIKeyForbidden = "forbidden"
+	APIKeyTooLarge  = "too_large"
+	APIKeyError     = "error"
+
+	BcryptHash    = "hash"
+	BcryptCompare = "compare"
+)
+
+var (
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method, route and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	Logins = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "logins_total",
+		Help:      "Login attempts by outcome.",
+	}, []string{"outcome"})
+
+	Registrations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "registrations_total",
+		Help:      "Registrations by outcome.",
+	}, []string{"outcome"})
+
+	RateLimitRejections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limit_rejections_total",
+		Help:      "Requests rejected by the request rate limiter, the login throttle or the password reset throttle.",
+	}, []string{"limiter"})
+
+	JWTFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "jwt_validation_failures_total",
+		Help:      "Rejected bearer tokens by reason.",
+	}, []string{"reason"})
+
+	APIKeyFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "api_key_failures_total",
+		Help:      "Rejected service API keys by reason.",
+	}, []string{"reason"})
+
+	BcryptDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "bcrypt_duration_seconds",
+		Help:      "Time spent hashing and comparing passwords with bcrypt.",
+		Buckets:   []float64{.01, .025, .05, .075, .1, .15, .2, .3, .5, 1},
+	}, []string{"operation"})
+)
@@ -0,0 +1,38 @@
+package requestid
+
+import (
+	"context"
+	"regexp"
+	"user-service/constants"
+
+	"github.com/google/uuid"
+)
+
+const maxLength = 128
+
+var pattern = regexp.MustCompile(`^[A-Za-z0-9._:-]+$`)
+
+// New returns a fresh request ID.
+func New() string {
+	return uuid.NewString()
+}
+
+// Valid reports whether an ID sent by a client can be reused. Anything longer
+// or with other characters is replaced, so it cannot be used to forge log
+// lines or headers.
+func Valid(id string) bool {
+	return len(id) > 0 && len(id) <= maxLength && pattern.MatchString(id)
+}
+
+func WithContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, constants.ContextKeyRequestID, id)
+}
+
+// FromContext returns the request ID in the context, or "" outside a request.
+func FromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(constants.ContextKeyRequestID).(string)
+	return id
+}
@@ -3,35 +3,45 @@ package response
 import (
 	"net/http"
 
+	"user-service/common/requestid"
 	"user-service/constants"
 	errConstant "user-service/constants/error"
+	"user-service/domain/dto"
 
 	"github.com/gin-gonic/gin"
 )
 
 type Response struct {
-	Status  string      `json:"status"`
-	Message any         `json:"message"`
-	Data    interface{} `json:"data"`
-	Token   *string     `json:"token,omitempty"`
+	Status       string          `json:"status"`
+	Message      any             `json:"message"`
+	Data         interface{}     `json:"data"`
+	Token        *string         `json:"token,omitempty"`
+	RefreshToken *string         `json:"refreshToken,omitempty"`
+	Pagination   *dto.Pagination `json:"pagination,omitempty"`
+	RequestID    string          `json:"requestId,omitempty"`
 }
 
 type ParamHttpResp struct {
-	Code    int
-	Err     error
-	Message *string
-	Gin     *gin.Context
-	Data    interface{}
-	Token   *string
+	Code         int
+	Err          error
+	Message      *string
+	Gin          *gin.Context
+	Data         interface{}
+	Token        *string
+	RefreshToken *string
+	Pagination   *dto.Pagination
 }
 
 func HttpResponse(param ParamHttpResp) {
 	if param.Err == nil {
 		param.Gin.JSON(param.Code, Response{
-			Status:  constants.Success,
-			Message: http.StatusText(http.StatusOK),
-			Data:    param.Data,
-			Token:   param.Token,
+			Status:       constants.Success,
+			Message:      http.StatusText(http.StatusOK),
+			Data:         param.Data,
+			Token:        param.Token,
+			RefreshToken: param.RefreshToken,
+			Pagination:   param.Pagination,
+			RequestID:    requestid.FromContext(param.Gin.Request.Context()),
 		})
 		return
 	}
@@ -46,9 +56,10 @@ func HttpResponse(param ParamHttpResp) {
 	}
 
 	param.Gin.JSON(param.Code, Response{
-		Status:  constants.Error,
-		Message: message,
-		Data:    param.Data,
+		Status:    constants.Error,
+		Message:   message,
+		Data:      param.Data,
+		RequestID: requestid.FromContext(param.Gin.Request.Context()),
 	})
 
 }
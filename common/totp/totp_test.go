@@ -0,0 +1,83 @@
+package totp
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA-1 seed of RFC 6238 appendix B, "12345678901234567890".
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+// The RFC lists 8-digit codes; with 6 digits they are the last six.
+func TestCodeAtRFC6238Vectors(t *testing.T) {
+	vectors := []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1111111111, code: "050471"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 2000000000, code: "279037"},
+		{unix: 20000000000, code: "353130"},
+	}
+
+	for _, vector := range vectors {
+		got, err := CodeAt(rfcSecret, Step(time.Unix(vector.unix, 0)))
+		if err != nil {
+			t.Fatalf("CodeAt(%d): %v", vector.unix, err)
+		}
+		if got != vector.code {
+			t.Errorf("CodeAt(%d) = %s, want %s", vector.unix, got, vector.code)
+		}
+	}
+}
+
+func TestValidateSkewWindow(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := Step(now)
+
+	tests := []struct {
+		name     string
+		offset   int64
+		skew     int
+		accepted bool
+	}{
+		{name: "current step", offset: 0, skew: 0, accepted: true},
+		{name: "previous step without skew", offset: -1, skew: 0, accepted: false},
+		{name: "previous step within skew", offset: -1, skew: 1, accepted: true},
+		{name: "next step within skew", offset: 1, skew: 1, accepted: true},
+		{name: "two steps behind", offset: -2, skew: 1, accepted: false},
+		{name: "two steps ahead", offset: 2, skew: 1, accepted: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := CodeAt(rfcSecret, current+tt.offset)
+			if err != nil {
+				t.Fatalf("CodeAt: %v", err)
+			}
+
+			step, ok := Validate(rfcSecret, code, now, tt.skew)
+			if ok != tt.accepted {
+				t.Fatalf("Validate accepted = %v, want %v", ok, tt.accepted)
+			}
+			if ok && step != current+tt.offset {
+				t.Errorf("Validate step = %d, want %d", step, current+tt.offset)
+			}
+		})
+	}
+}
+
+func TestValidateRejectsMalformedCodes(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	for _, code := range []string{"", "05047", "0504710", "abcdef"} {
+		if _, ok := Validate(rfcSecret, code, now, 1); ok {
+			t.Errorf("Validate(%q) accepted", code)
+		}
+	}
+	if _, ok := Validate("not base32!", "050471", now, 1); ok {
+		t.Error("Validate accepted a code for an invalid secret")
+	}
+}
@@ -0,0 +1,135 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"user-service/common/tracing"
+	"user-service/domain/dto"
+	userRepositories "user-service/repositories/user"
+	userServices "user-service/services/user"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const (
+	traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	traceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+	parentID    = "00f067aa0ba902b7"
+	userUUID    = "4bf92f35-77b3-4da6-a3ce-929d0e0e4736"
+)
+
+// userService stands in for the real service and only looks the user up
+// through the repository, which is enough to produce every layer of spans.
+type userService struct {
+	userServices.IUserService
+	repository userRepositories.IUserRepository
+}
+
+func (s *userService) GetUserByUUID(ctx context.Context, uuid string) (*dto.UserResponse, error) {
+	_, _ = s.repository.FindByUUID(ctx, uuid)
+	return &dto.UserResponse{}, nil
+}
+
+// newDryRunDB builds SQL without connecting to Postgres, so the GORM
+// callbacks run but no query is sent.
+func newDryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost user=test dbname=test"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		t.Fatalf("open dry run db: %v", err)
+	}
+	err = db.Use(tracing.NewGormPlugin())
+	if err != nil {
+		t.Fatalf("register gorm plugin: %v", err)
+	}
+	return db
+}
+
+func TestSpansFromHTTPToGorm(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	exporter := tracetest.NewInMemoryExporter()
+	provider := tracing.InitWithExporter(exporter, 1)
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+
+	repository := userRepositories.NewTracedUserRepository(userRepositories.NewUserRepository(newDryRunDB(t)))
+	service := userServices.NewTracedUserService(&userService{repository: repository})
+
+	router := gin.New()
+	router.Use(otelgin.Middleware("user-service"))
+	router.GET("/users/:uuid", func(c *gin.Context) {
+		_, _ = service.GetUserByUUID(c.Request.Context(), c.Param("uuid"))
+		c.Status(http.StatusOK)
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/users/"+userUUID, nil)
+	request.Header.Set("traceparent", traceparent)
+	router.ServeHTTP(httptest.NewRecorder(), request)
+
+	err := provider.ForceFlush(context.Background())
+	if err != nil {
+		t.Fatalf("flush spans: %v", err)
+	}
+
+	spans := map[string]tracetest.SpanStub{}
+	for _, span := range exporter.GetSpans() {
+		spans[span.Name] = span
+	}
+
+	chain := []struct {
+		name   string
+		parent string
+	}{
+		{name: "/users/:uuid"},
+		{name: "UserService.GetUserByUUID", parent: "/users/:uuid"},
+		{name: "UserRepository.FindByUUID", parent: "UserService.GetUserByUUID"},
+		{name: "gorm.Query", parent: "UserRepository.FindByUUID"},
+	}
+	for _, link := range chain {
+		span, ok := spans[link.name]
+		if !ok {
+			t.Fatalf("span %q not recorded, got %v", link.name, names(spans))
+		}
+		if got := span.SpanContext.TraceID().String(); got != traceID {
+			t.Errorf("span %q trace id = %s, want %s from traceparent", link.name, got, traceID)
+		}
+
+		wantParent := parentID
+		if link.parent != "" {
+			wantParent = spans[link.parent].SpanContext.SpanID().String()
+		}
+		if got := span.Parent.SpanID().String(); got != wantParent {
+			t.Errorf("span %q parent = %s, want %s", link.name, got, wantParent)
+		}
+	}
+
+	var statement string
+	for _, attribute := range spans["gorm.Query"].Attributes {
+		if attribute.Key == "db.query.text" {
+			statement = attribute.Value.AsString()
+		}
+	}
+	if statement == "" {
+		t.Fatal("gorm.Query has no db.query.text")
+	}
+	if strings.Contains(statement, userUUID) {
+		t.Errorf("db.query.text %q contains the bound uuid", statement)
+	}
+}
+
+func names(spans map[string]tracetest.SpanStub) []string {
+	result := make([]string, 0, len(spans))
+	for name := range spans {
+		result = append(result, name)
+	}
+	return result
+}
@@ -1,9 +1,12 @@
 package util
 
 import (
+	"crypto/rand"
+	"math/big"
 	"os"
 	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
@@ -99,3 +102,24 @@ func BindFromConsul(dest any, endPoint, path string) error {
 
 	return nil
 }
+
+// NormalizeIdentifier returns the canonical form of a username or email
+// address, which is how they are stored and compared.
+func NormalizeIdentifier(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// GeneratePassword returns a random password of length characters from an
+// alphabet without look-alike characters.
+func GeneratePassword(length int) (string, error) {
+	const alphabet = "abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	password := make([]byte, length)
+	for i := range password {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(alphabet))))
+		if err != nil {
+			return "", err
+		}
+		password[i] = alphabet[n.Int64()]
+	}
+	return string(password), nil
+}
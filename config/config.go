@@ -2,6 +2,7 @@ package config
 
 import (
 	"os"
+	"strings"
 	"user-service/common/util"
 
 	"github.com/sirupsen/logrus"
@@ -11,16 +12,91 @@ import (
 var Config AppConfig
 
 type AppConfig struct {
-	Port                   int      `json:"port"`
-	AppName                string   `json:"appName"`
-	AppEnv                 string   `json:"appEnv"`
-	SignatureKey           string   `json:"signatureKey"`
-	Database               Database `json:"database"`
-	EnableRateLimiter      bool     `json:"enableRateLimiter"`
-	RateLimiterMaxRequests float64  `json:"rateLimiterMaxRequests"`
-	RateLimiterTimeSeconds int      `json:"rateLimiterTimeSeconds"`
-	JwtSecretKey           string   `json:"jwtSecretKey"`
-	JwtExpirationTime      int      `json:"jwtExpirationTime"`
+	Port                            int            `json:"port"`
+	AppName                         string         `json:"appName"`
+	AppEnv                          string         `json:"appEnv"`
+	LogLevel                        string         `json:"logLevel"`
+	Tracing                         Tracing        `json:"tracing"`
+	HealthCheckTimeoutSeconds       int            `json:"healthCheckTimeoutSeconds"`
+	APIKeyClockSkewSeconds          int            `json:"apiKeyClockSkewSeconds"`
+	ServiceClientCacheTTLSeconds    int            `json:"serviceClientCacheTTLSeconds"`
+	ServiceClientRotationOverlap    int            `json:"serviceClientRotationOverlap"`
+	Database                        Database       `json:"database"`
+	EnableRateLimiter               bool           `json:"enableRateLimiter"`
+	RateLimiterMaxRequests          float64        `json:"rateLimiterMaxRequests"`
+	RateLimiterTimeSeconds          int            `json:"rateLimiterTimeSeconds"`
+	JwtSecretKey                    string         `json:"jwtSecretKey"`
+	JwtExpirationTime               int            `json:"jwtExpirationTime"`
+	JwtSigningKeyID                 string         `json:"jwtSigningKeyId"`
+	JwtKeys                         []JwtKey       `json:"jwtKeys"`
+	RefreshTokenExpirationTime      int            `json:"refreshTokenExpirationTime"`
+	RevocationCacheTTLSeconds       int            `json:"revocationCacheTTLSeconds"`
+	PermissionCacheTTLSeconds       int            `json:"permissionCacheTTLSeconds"`
+	Notifier                        Notifier       `json:"notifier"`
+	PasswordResetURL                string         `json:"passwordResetUrl"`
+	PasswordResetExpiration         int            `json:"passwordResetExpiration"`
+	RequireEmailVerification        bool           `json:"requireEmailVerification"`
+	EmailVerificationURL            string         `json:"emailVerificationUrl"`
+	EmailVerificationExpiration     int            `json:"emailVerificationExpiration"`
+	EmailVerificationResendInterval int            `json:"emailVerificationResendInterval"`
+	EncryptionKey                   string         `json:"encryptionKey"`
+	MFATokenExpiration              int            `json:"mfaTokenExpiration"`
+	LoginThrottle                   LoginThrottle  `json:"loginThrottle"`
+	PasswordResetThrottle           LoginThrottle  `json:"passwordResetThrottle"`
+	AllowPhoneLogin                 bool           `json:"allowPhoneLogin"`
+	TrustedProxies                  []string       `json:"trustedProxies"`
+	BootstrapAdmin                  BootstrapAdmin `json:"bootstrapAdmin"`
+}
+
+// Tracing selects where spans are exported: "otlp" (OTLP over HTTP to
+// Endpoint), "stdout", or "none". SampleRatio defaults to 1 when not set.
+type Tracing struct {
+	Exporter    string  `json:"exporter"`
+	Endpoint    string  `json:"endpoint"`
+	Insecure    bool    `json:"insecure"`
+	SampleRatio float64 `json:"sampleRatio"`
+}
+
+// BootstrapAdmin is the first admin account, created by the admin seeder when
+// no admin exists. Leave Password empty to have one generated and written to
+// PasswordFile.
+type BootstrapAdmin struct {
+	Username     string `json:"username"`
+	Email        string `json:"email"`
+	Password     string `json:"password"`
+	PasswordFile string `json:"passwordFile"`
+	Name         string `json:"name"`
+	PhoneNumber  string `json:"phoneNumber"`
+}
+
+// JwtKey is a PEM encoded RSA or Ed25519 key. Keys with only a public key are
+// kept for verifying tokens signed before a rotation.
+type JwtKey struct {
+	KeyID          string `json:"keyId"`
+	PrivateKeyPath string `json:"privateKeyPath"`
+	PublicKeyPath  string `json:"publicKeyPath"`
+}
+
+type LoginThrottle struct {
+	MaxAttempts        int `json:"maxAttempts"`
+	MaxAttemptsPerIP   int `json:"maxAttemptsPerIp"`
+	BackoffBaseSeconds int `json:"backoffBaseSeconds"`
+	LockoutMinutes     int `json:"lockoutMinutes"`
+	WindowMinutes      int `json:"windowMinutes"`
+}
+
+type Notifier struct {
+	Driver   string `json:"driver"`
+	FilePath string `json:"filePath"`
+	SMTP     SMTP   `json:"smtp"`
+}
+
+type SMTP struct {
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	From     string `json:"from"`
 }
 
 type Database struct {
@@ -35,6 +111,16 @@ type Database struct {
 	MaxIdleTime            int    `json:"maxIdleTime"`
 }
 
+// IsDevelopment reports whether AppEnv is a local or development environment,
+// the only ones where demo data is seeded.
+func IsDevelopment() bool {
+	switch strings.ToLower(Config.AppEnv) {
+	case "local", "dev", "development":
+		return true
+	}
+	return false
+}
+
 func Init() {
 	err := util.BindFromJSON(&Config, "config.json", ".")
 	if err != nil {
@@ -1,10 +1,13 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -33,5 +36,11 @@ func InitDatabase() (*gorm.DB, error) {
 	sqlDB.SetMaxOpenConns(config.Database.MaxOpenConnections)
 	sqlDB.SetConnMaxLifetime(time.Duration(config.Database.MaxLifetimeConnections) * time.Second)
 	sqlDB.SetConnMaxIdleTime(time.Duration(config.Database.MaxIdleTime) * time.Second)
+
+	// Exposes open, in-use and idle connections and wait counts on /metrics.
+	err = prometheus.Register(collectors.NewDBStatsCollector(sqlDB, config.Database.Name))
+	if err != nil && !errors.As(err, &prometheus.AlreadyRegisteredError{}) {
+		return nil, err
+	}
 	return db, nil
 }
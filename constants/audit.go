@@ -0,0 +1,27 @@
+package constants
+
+const (
+	AuditActionUserUpdate     = "user.update"
+	AuditActionPasswordReset  = "user.password_reset"
+	AuditActionMFAEnabled     = "user.mfa_enabled"
+	AuditActionMFADisabled    = "user.mfa_disabled"
+	AuditActionUserUnlock     = "user.unlock"
+	AuditActionDeduplicate    = "user.deduplicate"
+	AuditActionUserSuspend    = "user.suspend"
+	AuditActionUserReactivate = "user.reactivate"
+	AuditActionUserDelete     = "user.delete"
+	AuditActionUserClose      = "user.close"
+	AuditActionLogin          = "user.login"
+	AuditActionUserErase      = "user.erase"
+	AuditActionPasswordChange = "user.password_change"
+
+	AuditActionServiceClientIssue  = "service_client.issue"
+	AuditActionServiceClientRotate = "service_client.rotate"
+	AuditActionServiceClientRevoke = "service_client.revoke"
+)
+
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeDenied  = "denied"
+	AuditOutcomeFailure = "failure"
+)
@@ -1,6 +1,8 @@
 package constants
 
 const (
-	UserLogin = "user_login"
-	Token     = "token"
+	UserLogin    = "user_login"
+	ServiceLogin = "service_login"
+	Token        = "token"
+	Claims       = "claims"
 )
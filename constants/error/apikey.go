@@ -0,0 +1,24 @@
+package error
+
+import "errors"
+
+var (
+	ErrInvalidAPIKey  = errors.New("invalid api key")
+	ErrAPIKeyExpired  = errors.New("api key request time is outside the allowed window")
+	ErrAPIKeyReplayed = errors.New("api key request has already been used")
+
+	ErrServiceClientNotFound = errors.New("service client not found")
+	ErrServiceClientExist    = errors.New("service client already exists")
+	ErrServiceClientRevoked  = errors.New("service client has been revoked")
+	ErrInvalidServiceScope   = errors.New("invalid service client scope")
+)
+
+var APIKeyErrors = []error{
+	ErrInvalidAPIKey,
+	ErrAPIKeyExpired,
+	ErrAPIKeyReplayed,
+	ErrServiceClientNotFound,
+	ErrServiceClientExist,
+	ErrServiceClientRevoked,
+	ErrInvalidServiceScope,
+}
@@ -3,6 +3,9 @@ package error
 func ErrMapping(err error) bool {
 	// allErrors := make([]error, 0)
 	allErrors := append(GeneralErrors[:], UserError[:]...)
+	allErrors = append(allErrors, TokenErrors...)
+	allErrors = append(allErrors, MFAErrors...)
+	allErrors = append(allErrors, APIKeyErrors...)
 	for _, item := range allErrors {
 		if err.Error() == item.Error() {
 			return true
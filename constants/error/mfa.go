@@ -0,0 +1,19 @@
+package error
+
+import "errors"
+
+var (
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrMFANotEnrolled    = errors.New("two-factor authentication enrollment not found")
+	ErrMFANotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrInvalidMFACode    = errors.New("invalid authentication code")
+	ErrInvalidMFAToken   = errors.New("invalid or expired mfa token")
+)
+
+var MFAErrors = []error{
+	ErrMFAAlreadyEnabled,
+	ErrMFANotEnrolled,
+	ErrMFANotEnabled,
+	ErrInvalidMFACode,
+	ErrInvalidMFAToken,
+}
@@ -0,0 +1,19 @@
+package error
+
+import "errors"
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenExpired = errors.New("refresh token expired")
+	ErrRefreshTokenReused  = errors.New("refresh token has already been used")
+	ErrTokenRevoked        = errors.New("token has been revoked")
+	ErrInvalidUserToken    = errors.New("invalid or expired token")
+)
+
+var TokenErrors = []error{
+	ErrInvalidRefreshToken,
+	ErrRefreshTokenExpired,
+	ErrRefreshTokenReused,
+	ErrTokenRevoked,
+	ErrInvalidUserToken,
+}
@@ -3,16 +3,33 @@ package error
 import "errors"
 
 var (
-	ErrUserNotFound         = errors.New("user not found")
-	ErrPasswordIncorrect    = errors.New("password incorrect")
-	ErrUsernameExist        = errors.New("username already exists")
-	ErrEmailExist           = errors.New("email already exists")
-	ErrPasswordDoesNotMatch = errors.New("password does not match")
+	ErrUserNotFound           = errors.New("user not found")
+	ErrPasswordIncorrect      = errors.New("password incorrect")
+	ErrUsernameExist          = errors.New("username already exists")
+	ErrEmailExist             = errors.New("email already exists")
+	ErrPasswordDoesNotMatch   = errors.New("password does not match")
+	ErrInvalidCursor          = errors.New("invalid cursor")
+	ErrEmailNotVerified       = errors.New("email address has not been verified")
+	ErrInvalidCredentials     = errors.New("invalid username or password")
+	ErrLoginLocked            = errors.New("too many failed login attempts, please try again later")
+	ErrUserInactive           = errors.New("user account is not active")
+	ErrRoleNotFound           = errors.New("role not found")
+	ErrPasswordChangeRequired = errors.New("password change required")
+	ErrPasswordUnchanged      = errors.New("new password must differ from the current password")
 )
 
 var UserError = []error{
 	ErrUserNotFound,
 	ErrPasswordIncorrect,
 	ErrUsernameExist,
+	ErrEmailExist,
 	ErrPasswordDoesNotMatch,
+	ErrInvalidCursor,
+	ErrEmailNotVerified,
+	ErrInvalidCredentials,
+	ErrLoginLocked,
+	ErrUserInactive,
+	ErrRoleNotFound,
+	ErrPasswordChangeRequired,
+	ErrPasswordUnchanged,
 }
@@ -6,5 +6,7 @@ var (
 	XserviceName  = textproto.CanonicalMIMEHeaderKey("x-service-name")
 	XApiKey       = textproto.CanonicalMIMEHeaderKey("x-api-key")
 	XRequestAt    = textproto.CanonicalMIMEHeaderKey("x-request-at")
+	XNonce        = textproto.CanonicalMIMEHeaderKey("x-nonce")
+	XRequestID    = textproto.CanonicalMIMEHeaderKey("x-request-id")
 	Authorization = textproto.CanonicalMIMEHeaderKey("x-authorization")
 )
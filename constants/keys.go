@@ -5,4 +5,6 @@ type ContextKey string
 const (
 	ContextKeyUserLogin ContextKey = "userLogin"
 	ContextKeyToken     ContextKey = "token"
+	ContextKeyLogger    ContextKey = "logger"
+	ContextKeyRequestID ContextKey = "requestID"
 )
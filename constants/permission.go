@@ -0,0 +1,10 @@
+package constants
+
+const (
+	PermissionUserRead   = "user:read"
+	PermissionUserUpdate = "user:update"
+	PermissionUserList   = "user:list"
+	PermissionUserManage = "user:manage"
+
+	PermissionServiceClientManage = "service_client:manage"
+)
@@ -4,3 +4,9 @@ const (
 	Admin    = 1
 	Customer = 2
 )
+
+// Role codes as they appear in the JWT user claim.
+const (
+	RoleAdmin    = "admin"
+	RoleCustomer = "customer"
+)
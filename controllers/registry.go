@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	serviceClientControllers "user-service/controllers/serviceclient"
 	controllers "user-service/controllers/user"
 	"user-service/services"
 )
@@ -11,6 +12,7 @@ type Registry struct {
 
 type IControllerRegistry interface {
 	GetUserController() controllers.IUserController
+	GetServiceClientController() serviceClientControllers.IServiceClientController
 }
 
 func NewControllerRegistry(service services.IServiceRegistry) IControllerRegistry {
@@ -20,3 +22,7 @@ func NewControllerRegistry(service services.IServiceRegistry) IControllerRegistr
 func (u *Registry) GetUserController() controllers.IUserController {
 	return controllers.NewUserController(u.service)
 }
+
+func (u *Registry) GetServiceClientController() serviceClientControllers.IServiceClientController {
+	return serviceClientControllers.NewServiceClientController(u.service)
+}
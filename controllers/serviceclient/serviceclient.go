@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"net/http"
+	errWrap "user-service/common/error"
+	"user-service/common/response"
+	"user-service/domain/dto"
+	"user-service/services"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+type ServiceClientController struct {
+	service services.IServiceRegistry
+}
+
+type IServiceClientController interface {
+	GetServiceClients(*gin.Context)
+	IssueServiceClient(*gin.Context)
+	RotateServiceClient(*gin.Context)
+	RevokeServiceClient(*gin.Context)
+}
+
+func NewServiceClientController(service services.IServiceRegistry) IServiceClientController {
+	return &ServiceClientController{service: service}
+}
+
+func (s *ServiceClientController) GetServiceClients(ctx *gin.Context) {
+	clients, err := s.service.GetAPIKey().GetClients(ctx.Request.Context())
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Data: clients,
+		Gin:  ctx,
+	})
+}
+
+func (s *ServiceClientController) IssueServiceClient(ctx *gin.Context) {
+	request := &dto.ServiceClientRequest{}
+
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errWrap.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHttpResp{
+			Code:    http.StatusBadRequest,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	client, err := s.service.GetAPIKey().IssueClient(ctx.Request.Context(), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHttpResp{
+		Code: http.StatusCreated,
+		Data: client,
+		Gin:  ctx,
+	})
+}
+
+func (s *ServiceClientController) RotateServiceClient(ctx *gin.Context) {
+	request := &dto.RotateServiceClientRequest{}
+
+	// The body is optional; without it the configured overlap is used.
+	if ctx.Request.ContentLength != 0 {
+		err := ctx.ShouldBindJSON(request)
+		if err != nil {
+			response.HttpResponse(response.ParamHttpResp{
+				Code: http.StatusBadRequest,
+				Err:  err,
+				Gin:  ctx,
+			})
+			return
+		}
+	}
+
+	validate := validator.New()
+	err := validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errWrap.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHttpResp{
+			Code:    http.StatusBadRequest,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	client, err := s.service.GetAPIKey().RotateClient(ctx.Request.Context(), ctx.Param("name"), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Data: client,
+		Gin:  ctx,
+	})
+}
+
+func (s *ServiceClientController) RevokeServiceClient(ctx *gin.Context) {
+	err := s.service.GetAPIKey().RevokeClient(ctx.Request.Context(), ctx.Param("name"))
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
@@ -1,10 +1,13 @@
 package controllers
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	errWrap "user-service/common/error"
 	"user-service/common/response"
+	errConstant "user-service/constants/error"
 	"user-service/domain/dto"
 	"user-service/services"
 
@@ -22,6 +25,26 @@ type IUserController interface {
 	Update(*gin.Context)
 	GetUserLogin(*gin.Context)
 	GetUserByUUID(*gin.Context)
+	Refresh(*gin.Context)
+	Logout(*gin.Context)
+	LogoutAll(*gin.Context)
+	GetUsers(*gin.Context)
+	ForgotPassword(*gin.Context)
+	ResetPassword(*gin.Context)
+	ChangePassword(*gin.Context)
+	VerifyEmail(*gin.Context)
+	ResendEmailVerification(*gin.Context)
+	LoginMFA(*gin.Context)
+	EnrollMFA(*gin.Context)
+	ConfirmMFA(*gin.Context)
+	DisableMFA(*gin.Context)
+	UnlockUser(*gin.Context)
+	SuspendUser(*gin.Context)
+	ReactivateUser(*gin.Context)
+	DeleteUser(*gin.Context)
+	CloseAccount(*gin.Context)
+	ExportUserData(*gin.Context)
+	EraseUser(*gin.Context)
 }
 
 func NewUserController(service services.IServiceRegistry) IUserController {
@@ -29,12 +52,10 @@ func NewUserController(service services.IServiceRegistry) IUserController {
 }
 
 func (u *UserController) Login(ctx *gin.Context) {
-	fmt.Println("[DEBUG] Login handler dipanggil")
 	request := &dto.LoginRequest{}
 
 	err := ctx.ShouldBindJSON(request)
 	if err != nil {
-		fmt.Println("[ERROR] Gagal binding JSON:", err)
 		response.HttpResponse(response.ParamHttpResp{
 			Code: http.StatusBadRequest,
 			Err:  err,
@@ -42,12 +63,11 @@ func (u *UserController) Login(ctx *gin.Context) {
 		})
 		return
 	}
-	fmt.Printf("[INFO] Payload masuk: Username=%s, Password=%s\n", request.Username, request.Password)
+	request.IPAddress = ctx.ClientIP()
 
 	validate := validator.New()
 	err = validate.Struct(request)
 	if err != nil {
-		fmt.Println("[ERROR] Validasi request gagal:", err)
 		errMessage := http.StatusText(http.StatusUnprocessableEntity)
 		errResponse := errWrap.ErrValidationResponse(err)
 		response.HttpResponse(response.ParamHttpResp{
@@ -60,23 +80,37 @@ func (u *UserController) Login(ctx *gin.Context) {
 		return
 	}
 
-	user, err := u.service.GetUser().Login(ctx, request)
+	user, err := u.service.GetUser().Login(ctx.Request.Context(), request)
 	if err != nil {
-		fmt.Println("[ERROR] Login service gagal:", err)
+		code := http.StatusBadRequest
+		switch {
+		case errors.Is(err, errConstant.ErrLoginLocked):
+			code = http.StatusTooManyRequests
+		case errors.Is(err, errConstant.ErrUserInactive):
+			code = http.StatusForbidden
+		}
 		response.HttpResponse(response.ParamHttpResp{
-			Code: http.StatusBadRequest,
+			Code: code,
 			Err:  err,
 			Gin:  ctx,
 		})
 		return
 	}
-	fmt.Println("[INFO] Login berhasil, token akan dikirim")
+	if user.MFARequired {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusOK,
+			Data: dto.MFAChallengeResponse{MFARequired: true, MFAToken: user.MFAToken},
+			Gin:  ctx,
+		})
+		return
+	}
 
 	response.HttpResponse(response.ParamHttpResp{
-		Code:  http.StatusOK,
-		Data:  user.User,
-		Token: &user.Token,
-		Gin:   ctx,
+		Code:         http.StatusOK,
+		Data:         user.User,
+		Token:        &user.Token,
+		RefreshToken: &user.RefreshToken,
+		Gin:          ctx,
 	})
 }
 
@@ -108,7 +142,7 @@ func (u *UserController) Register(ctx *gin.Context) {
 		return
 	}
 
-	user, err := u.service.GetUser().Register(ctx, request)
+	user, err := u.service.GetUser().Register(ctx.Request.Context(), request)
 	if err != nil {
 		response.HttpResponse(response.ParamHttpResp{
 			Code: http.StatusBadRequest,
@@ -127,16 +161,11 @@ func (u *UserController) Register(ctx *gin.Context) {
 }
 
 func (u *UserController) Update(ctx *gin.Context) {
-	fmt.Println("🔍 [DEBUG-CONTROLLER] Update handler dipanggil")
-
 	request := &dto.UpdateRequest{}
 	uuid := ctx.Param("uuid")
-	fmt.Println("🔍 [DEBUG-CONTROLLER] UUID yang diterima dari parameter:", uuid)
 
-	fmt.Println("🔍 [DEBUG-CONTROLLER] Mencoba binding JSON request")
 	err := ctx.ShouldBindJSON(request)
 	if err != nil {
-		fmt.Println("❌ [ERROR-CONTROLLER] Gagal binding JSON:", err)
 		response.HttpResponse(response.ParamHttpResp{
 			Code: http.StatusBadRequest,
 			Err:  err,
@@ -144,13 +173,10 @@ func (u *UserController) Update(ctx *gin.Context) {
 		})
 		return
 	}
-	fmt.Printf("🔍 [DEBUG-CONTROLLER] Request data setelah binding: %v+\n", request)
 
-	fmt.Println("🔍 [DEBUG-CONTROLLER] Memulai validasi request")
 	validate := validator.New()
 	err = validate.Struct(request)
 	if err != nil {
-		fmt.Println("❌ [ERROR-CONTROLLER] Validasi request gagal:", err)
 		// Menggunakan http.StatusText untuk mendapatkan pesan status
 		errMessage := http.StatusText(http.StatusUnprocessableEntity)
 		errResponse := errWrap.ErrValidationResponse(err)
@@ -164,44 +190,35 @@ func (u *UserController) Update(ctx *gin.Context) {
 		return
 	}
 
-	fmt.Println("🔍 [DEBUG-CONTROLLER] Validasi berhasil, memanggil service untuk update user")
-	user, err := u.service.GetUser().Update(ctx, request, uuid)
+	user, err := u.service.GetUser().Update(ctx.Request.Context(), request, uuid)
 	if err != nil {
-		fmt.Println("❌ [ERROR-CONTROLLER] Gagal memanggil service untuk update user:", err)
+		code := http.StatusBadRequest
+		if errors.Is(err, errConstant.ErrForbidden) {
+			code = http.StatusForbidden
+		}
 		response.HttpResponse(response.ParamHttpResp{
-			Code: http.StatusBadRequest,
+			Code: code,
 			Err:  err,
 			Gin:  ctx,
 		})
 		return
 	}
-	fmt.Println("✅ [INFO-CONTROLLER] Update berhasil")
-	fmt.Printf("✅ [INFO-CONTROLLER] Data user setelah update: %v+\n", user)
 
 	response.HttpResponse(response.ParamHttpResp{
 		Code: http.StatusOK,
 		Data: user,
 		Gin:  ctx,
 	})
-	fmt.Println("✅ [INFO-CONTROLLER] Mengembalikan response ke client")
-	fmt.Println("✅ [INFO-CONTROLLER] Response berhasil dikirim")
-	fmt.Println("✅ [INFO-CONTROLLER] Proses update selesai")
-	fmt.Println("=======================================")
 }
 
 func (u *UserController) GetUserLogin(ctx *gin.Context) {
-	fmt.Println("🔍 [DEBUG-CONTROLLER] GetUserLogin handler dipanggil")
-	fmt.Println("🔍 [DEBUG-CONTROLLER] Mengambil data user dari context")
 	user, err := u.service.GetUser().GetUserLogin(ctx.Request.Context())
 	if err != nil {
-		fmt.Println("❌ [ERROR-CONTROLLER] Gagal mendapatkan user login:", err)
 		response.HttpResponse(response.ParamHttpResp{
 			Code: http.StatusBadRequest,
 			Err:  err,
 			Gin:  ctx,
 		})
-		fmt.Println("✅ [INFO-CONTROLLER] Mengembalikan response error")
-		fmt.Println("=======================================")
 		return
 	}
 
@@ -215,17 +232,13 @@ func (u *UserController) GetUserLogin(ctx *gin.Context) {
 func (u *UserController) GetUserByUUID(ctx *gin.Context) {
 	user, err := u.service.GetUser().GetUserByUUID(ctx.Request.Context(), ctx.Param("uuid"))
 	if err != nil {
-		fmt.Println("❌ [ERROR-CONTROLLERS] Gagal mendapatkan user:", err)
 		response.HttpResponse(response.ParamHttpResp{
 			Code: http.StatusBadRequest,
 			Err:  err,
 			Gin:  ctx,
 		})
-		fmt.Println("✅ [INFO-CONTROLLERS] Mengembalikan response error")
 		return
 	}
-	fmt.Println("✅ [INFO-CONTROLLERS] User ditemukan, mengembalikan data user")
-	fmt.Println("✅ [INFO-CONTROLLERS] Data user:", user)
 
 	response.HttpResponse(response.ParamHttpResp{
 		Code: http.StatusOK,
@@ -233,3 +246,650 @@ func (u *UserController) GetUserByUUID(ctx *gin.Context) {
 		Gin:  ctx,
 	})
 }
+
+func (u *UserController) Refresh(ctx *gin.Context) {
+	request := &dto.RefreshTokenRequest{}
+
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errWrap.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHttpResp{
+			Code:    http.StatusBadRequest,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	user, err := u.service.GetUser().Refresh(ctx.Request.Context(), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusUnauthorized,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHttpResp{
+		Code:         http.StatusOK,
+		Data:         user.User,
+		Token:        &user.Token,
+		RefreshToken: &user.RefreshToken,
+		Gin:          ctx,
+	})
+}
+
+func (u *UserController) Logout(ctx *gin.Context) {
+	request := &dto.LogoutRequest{}
+
+	if ctx.Request.ContentLength > 0 {
+		err := ctx.ShouldBindJSON(request)
+		if err != nil {
+			response.HttpResponse(response.ParamHttpResp{
+				Code: http.StatusBadRequest,
+				Err:  err,
+				Gin:  ctx,
+			})
+			return
+		}
+	}
+
+	err := u.service.GetUser().Logout(ctx.Request.Context(), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (u *UserController) LogoutAll(ctx *gin.Context) {
+	err := u.service.GetUser().LogoutAll(ctx.Request.Context())
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (u *UserController) GetUsers(ctx *gin.Context) {
+	request := &dto.UserListRequest{}
+
+	err := ctx.ShouldBindQuery(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errWrap.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHttpResp{
+			Code:    http.StatusBadRequest,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	users, err := u.service.GetUser().GetUsers(ctx.Request.Context(), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHttpResp{
+		Code:       http.StatusOK,
+		Data:       users.Users,
+		Pagination: &users.Pagination,
+		Gin:        ctx,
+	})
+}
+
+func (u *UserController) ForgotPassword(ctx *gin.Context) {
+	request := &dto.ForgotPasswordRequest{}
+
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+	request.IPAddress = ctx.ClientIP()
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errWrap.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHttpResp{
+			Code:    http.StatusBadRequest,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	err = u.service.GetUser().ForgotPassword(ctx.Request.Context(), request)
+	if err != nil {
+		code := http.StatusBadRequest
+		if errors.Is(err, errConstant.ErrTooManyRequests) {
+			code = http.StatusTooManyRequests
+		}
+		response.HttpResponse(response.ParamHttpResp{
+			Code: code,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (u *UserController) ResetPassword(ctx *gin.Context) {
+	request := &dto.ResetPasswordRequest{}
+
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errWrap.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHttpResp{
+			Code:    http.StatusBadRequest,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	err = u.service.GetUser().ResetPassword(ctx.Request.Context(), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (u *UserController) ChangePassword(ctx *gin.Context) {
+	request := &dto.ChangePasswordRequest{}
+
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errWrap.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHttpResp{
+			Code:    http.StatusBadRequest,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	request.IPAddress = ctx.ClientIP()
+	user, err := u.service.GetUser().ChangePassword(ctx.Request.Context(), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHttpResp{
+		Code:         http.StatusOK,
+		Data:         user.User,
+		Token:        &user.Token,
+		RefreshToken: &user.RefreshToken,
+		Gin:          ctx,
+	})
+}
+
+func (u *UserController) VerifyEmail(ctx *gin.Context) {
+	request := &dto.VerifyEmailRequest{}
+
+	err := ctx.ShouldBind(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errWrap.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHttpResp{
+			Code:    http.StatusBadRequest,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	err = u.service.GetUser().VerifyEmail(ctx.Request.Context(), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (u *UserController) ResendEmailVerification(ctx *gin.Context) {
+	request := &dto.ResendEmailVerificationRequest{}
+
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errWrap.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHttpResp{
+			Code:    http.StatusBadRequest,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	err = u.service.GetUser().ResendEmailVerification(ctx.Request.Context(), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (u *UserController) LoginMFA(ctx *gin.Context) {
+	request := &dto.LoginMFARequest{}
+
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errWrap.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHttpResp{
+			Code:    http.StatusBadRequest,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	request.IPAddress = ctx.ClientIP()
+	user, err := u.service.GetUser().LoginMFA(ctx.Request.Context(), request)
+	if err != nil {
+		code := http.StatusUnauthorized
+		if errors.Is(err, errConstant.ErrLoginLocked) {
+			code = http.StatusTooManyRequests
+		}
+		response.HttpResponse(response.ParamHttpResp{
+			Code: code,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHttpResp{
+		Code:         http.StatusOK,
+		Data:         user.User,
+		Token:        &user.Token,
+		RefreshToken: &user.RefreshToken,
+		Gin:          ctx,
+	})
+}
+
+func (u *UserController) EnrollMFA(ctx *gin.Context) {
+	enrollment, err := u.service.GetUser().EnrollMFA(ctx.Request.Context())
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Data: enrollment,
+		Gin:  ctx,
+	})
+}
+
+func (u *UserController) ConfirmMFA(ctx *gin.Context) {
+	request := &dto.MFACodeRequest{}
+
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errWrap.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHttpResp{
+			Code:    http.StatusBadRequest,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	codes, err := u.service.GetUser().ConfirmMFA(ctx.Request.Context(), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Data: codes,
+		Gin:  ctx,
+	})
+}
+
+func (u *UserController) DisableMFA(ctx *gin.Context) {
+	request := &dto.DisableMFARequest{}
+
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errWrap.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHttpResp{
+			Code:    http.StatusBadRequest,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	err = u.service.GetUser().DisableMFA(ctx.Request.Context(), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (u *UserController) UnlockUser(ctx *gin.Context) {
+	err := u.service.GetUser().UnlockUser(ctx.Request.Context(), ctx.Param("uuid"))
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+// manageUser runs an admin action on the user in the :uuid path parameter.
+func (u *UserController) manageUser(ctx *gin.Context, action func(context.Context, string) error) {
+	err := action(ctx.Request.Context(), ctx.Param("uuid"))
+	if err != nil {
+		code := http.StatusBadRequest
+		if errors.Is(err, errConstant.ErrForbidden) {
+			code = http.StatusForbidden
+		}
+		response.HttpResponse(response.ParamHttpResp{
+			Code: code,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+func (u *UserController) SuspendUser(ctx *gin.Context) {
+	u.manageUser(ctx, u.service.GetUser().SuspendUser)
+}
+
+func (u *UserController) ReactivateUser(ctx *gin.Context) {
+	u.manageUser(ctx, u.service.GetUser().ReactivateUser)
+}
+
+func (u *UserController) DeleteUser(ctx *gin.Context) {
+	u.manageUser(ctx, u.service.GetUser().DeleteUser)
+}
+
+func (u *UserController) CloseAccount(ctx *gin.Context) {
+	request := &dto.CloseAccountRequest{}
+
+	err := ctx.ShouldBindJSON(request)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	validate := validator.New()
+	err = validate.Struct(request)
+	if err != nil {
+		errMessage := http.StatusText(http.StatusUnprocessableEntity)
+		errResponse := errWrap.ErrValidationResponse(err)
+		response.HttpResponse(response.ParamHttpResp{
+			Code:    http.StatusBadRequest,
+			Message: &errMessage,
+			Data:    errResponse,
+			Err:     err,
+			Gin:     ctx,
+		})
+		return
+	}
+
+	err = u.service.GetUser().CloseAccount(ctx.Request.Context(), request)
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	response.HttpResponse(response.ParamHttpResp{
+		Code: http.StatusOK,
+		Gin:  ctx,
+	})
+}
+
+// ExportUserData sends the archive as a JSON file download rather than in the
+// response envelope.
+func (u *UserController) ExportUserData(ctx *gin.Context) {
+	export, err := u.service.GetUser().ExportUserData(ctx.Request.Context())
+	if err != nil {
+		response.HttpResponse(response.ParamHttpResp{
+			Code: http.StatusBadRequest,
+			Err:  err,
+			Gin:  ctx,
+		})
+		return
+	}
+
+	filename := fmt.Sprintf("user-%s.json", export.Profile.UUID)
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(http.StatusOK, export)
+}
+
+func (u *UserController) EraseUser(ctx *gin.Context) {
+	u.manageUser(ctx, u.service.GetUser().EraseUser)
+}
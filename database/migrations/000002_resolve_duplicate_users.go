@@ -0,0 +1,117 @@
+package migrations
+
+import (
+	"fmt"
+	"time"
+	"user-service/constants"
+	"user-service/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const maxUserNameLength = 20
+
+func init() {
+	register(Migration{
+		Version: 2,
+		Name:    "resolve_duplicate_users",
+		Up:      resolveDuplicateUsers,
+		Down:    execSQL(fmt.Sprintf("DROP INDEX IF EXISTS %s", models.UserUUIDIndex)),
+	})
+}
+
+type duplicateUser struct {
+	ID    uint
+	UUID  uuid.UUID
+	Value string
+}
+
+// findDuplicateUsers returns every user that shares expr with an older user.
+func findDuplicateUsers(tx *gorm.DB, expr string) ([]duplicateUser, error) {
+	var users []duplicateUser
+	err := tx.Raw(fmt.Sprintf(`
+		SELECT id, uuid, value FROM (
+			SELECT id, uuid, %[1]s AS value, ROW_NUMBER() OVER (PARTITION BY %[1]s ORDER BY id) AS position
+			FROM users
+		) ranked
+		WHERE position > 1
+		ORDER BY id`, expr),
+	).Scan(&users).Error
+	return users, err
+}
+
+func recordDeduplication(tx *gorm.DB, userUUID uuid.UUID, reason string) error {
+	logrus.Warnf("user %s: %s", userUUID, reason)
+	// Plain SQL so the migration does not depend on later versions of the
+	// models.
+	return tx.Exec(
+		"INSERT INTO audit_logs (action, target_uuid, outcome, reason, created_at) VALUES (?, ?, ?, ?, ?)",
+		constants.AuditActionDeduplicate, userUUID, constants.AuditOutcomeSuccess, reason, time.Now(),
+	).Error
+}
+
+// resolveDuplicateUsers makes uuids, usernames and emails unique so that the
+// unique indexes can be created. The oldest user keeps the value; newer ones
+// get a fresh uuid, a suffixed username or a placeholder email that has to be
+// verified again. Every change is logged and written to the audit log.
+// Rolling back only drops the uuid index.
+func resolveDuplicateUsers(tx *gorm.DB) error {
+	users, err := findDuplicateUsers(tx, "uuid")
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		newUUID := uuid.New()
+		err = tx.Table("users").Where("id = ?", user.ID).Update("uuid", newUUID).Error
+		if err != nil {
+			return err
+		}
+		err = recordDeduplication(tx, newUUID, fmt.Sprintf("duplicate uuid %s replaced", user.UUID))
+		if err != nil {
+			return err
+		}
+	}
+
+	users, err = findDuplicateUsers(tx, "LOWER(TRIM(user_name))")
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		suffix := fmt.Sprintf("_%d", user.ID)
+		prefix := user.Value
+		if len(prefix)+len(suffix) > maxUserNameLength {
+			prefix = prefix[:maxUserNameLength-len(suffix)]
+		}
+		err = tx.Table("users").Where("id = ?", user.ID).Update("user_name", prefix+suffix).Error
+		if err != nil {
+			return err
+		}
+		err = recordDeduplication(tx, user.UUID, fmt.Sprintf("duplicate username %q renamed to %q", user.Value, prefix+suffix))
+		if err != nil {
+			return err
+		}
+	}
+
+	users, err = findDuplicateUsers(tx, "LOWER(TRIM(email))")
+	if err != nil {
+		return err
+	}
+	for _, user := range users {
+		email := fmt.Sprintf("duplicate-%d@users.invalid", user.ID)
+		err = tx.Table("users").Where("id = ?", user.ID).Updates(map[string]any{
+			"email":             email,
+			"email_verified_at": nil,
+		}).Error
+		if err != nil {
+			return err
+		}
+		err = recordDeduplication(tx, user.UUID, fmt.Sprintf("duplicate email %q replaced with %q", user.Value, email))
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Exec(fmt.Sprintf(`CREATE UNIQUE INDEX IF NOT EXISTS %s ON users (uuid)`, models.UserUUIDIndex)).Error
+}
@@ -0,0 +1,82 @@
+package migrations
+
+import (
+	"fmt"
+	"user-service/domain/models"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+func init() {
+	register(Migration{
+		Version: 3,
+		Name:    "normalize_user_identifiers",
+		Up:      normalizeUserIdentifiers,
+		Down: execSQL(fmt.Sprintf(
+			"DROP INDEX IF EXISTS %s; DROP INDEX IF EXISTS %s",
+			models.UserUserNameIndex, models.UserEmailIndex,
+		)),
+	})
+}
+
+// IdentifierCollision is a group of users whose username or email only
+// differ in case or surrounding whitespace.
+type IdentifierCollision struct {
+	Column string
+	Value  string
+	UUIDs  string
+}
+
+// FindIdentifierCollisions lists the usernames and emails that would become
+// duplicates once they are normalized.
+func FindIdentifierCollisions(db *gorm.DB) ([]IdentifierCollision, error) {
+	var collisions []IdentifierCollision
+	for _, column := range []string{"user_name", "email"} {
+		var rows []IdentifierCollision
+		err := db.Raw(fmt.Sprintf(`
+			SELECT '%[1]s' AS "column", LOWER(TRIM(%[1]s)) AS value, STRING_AGG(uuid::text, ', ' ORDER BY id) AS uuids
+			FROM users
+			GROUP BY LOWER(TRIM(%[1]s))
+			HAVING COUNT(*) > 1`, column),
+		).Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+		collisions = append(collisions, rows...)
+	}
+	return collisions, nil
+}
+
+// normalizeUserIdentifiers lowercases and trims the stored usernames and
+// emails and adds case-insensitive unique indexes on both. Collisions are
+// reported and must be resolved by hand before the migration can run; the
+// previous migration already renames duplicates, so this is a safety net.
+// Rolling back only drops the indexes.
+func normalizeUserIdentifiers(tx *gorm.DB) error {
+	collisions, err := FindIdentifierCollisions(tx)
+	if err != nil {
+		return err
+	}
+	if len(collisions) > 0 {
+		for _, collision := range collisions {
+			logrus.Warnf("users [%s] collide on %s %q", collision.UUIDs, collision.Column, collision.Value)
+		}
+		return fmt.Errorf("found %d username/email collisions, resolve them before normalizing identifiers", len(collisions))
+	}
+
+	statements := []string{
+		`UPDATE users
+		SET user_name = LOWER(TRIM(user_name)), email = LOWER(TRIM(email)), phone_number = TRIM(phone_number)
+		WHERE user_name <> LOWER(TRIM(user_name)) OR email <> LOWER(TRIM(email)) OR phone_number <> TRIM(phone_number)`,
+		fmt.Sprintf(`CREATE UNIQUE INDEX IF NOT EXISTS %s ON users (LOWER(user_name))`, models.UserUserNameIndex),
+		fmt.Sprintf(`CREATE UNIQUE INDEX IF NOT EXISTS %s ON users (LOWER(email))`, models.UserEmailIndex),
+	}
+	for _, statement := range statements {
+		err := tx.Exec(statement).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// Migration is one versioned schema change. SQL migrations are embedded from
+// sql/<version>_<name>.up.sql and .down.sql; migrations that need Go code
+// register themselves with register.
+type Migration struct {
+	Version uint
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
+var (
+	goMigrations    []Migration
+	fileNamePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+)
+
+func register(migration Migration) {
+	goMigrations = append(goMigrations, migration)
+}
+
+func execSQL(statements string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		return tx.Exec(statements).Error
+	}
+}
+
+// All returns every known migration ordered by version.
+func All() ([]Migration, error) {
+	byVersion := map[uint]*Migration{}
+
+	entries, err := fs.ReadDir(sqlFiles, "sql")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %s", entry.Name())
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		content, err := sqlFiles.ReadFile(path.Join("sql", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[uint(version)]
+		if !ok {
+			migration = &Migration{Version: uint(version), Name: match[2]}
+			byVersion[migration.Version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has files with different names", version)
+		}
+		if match[3] == "up" {
+			migration.Up = execSQL(string(content))
+		} else {
+			migration.Down = execSQL(string(content))
+		}
+	}
+
+	for _, migration := range goMigrations {
+		if _, ok := byVersion[migration.Version]; ok {
+			return nil, fmt.Errorf("migration %d is defined more than once", migration.Version)
+		}
+		migration := migration
+		byVersion[migration.Version] = &migration
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == nil {
+			return nil, fmt.Errorf("migration %d_%s has no up migration", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
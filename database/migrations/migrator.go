@@ -0,0 +1,271 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// advisoryLockKey identifies this service's migrations in pg_advisory_lock so
+// that replicas starting together apply them one at a time.
+const advisoryLockKey int64 = 4_719_202_511
+
+var namePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// SchemaMigration is a row of schema_migrations, one per applied migration.
+type SchemaMigration struct {
+	Version   uint      `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"type:varchar(255);not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+type MigrationStatus struct {
+	Version   uint
+	Name      string
+	AppliedAt *time.Time
+	// Unknown is set for applied versions this build has no migration for,
+	// usually because a newer release already migrated the database.
+	Unknown bool
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	migrations, err := All()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// withLock runs fn on a single connection that holds the advisory lock and
+// has the schema_migrations table.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *gorm.DB) error) error {
+	return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		err := conn.Exec("SELECT pg_advisory_lock(?)", advisoryLockKey).Error
+		if err != nil {
+			return err
+		}
+		defer func() {
+			// Unlock even if ctx is done, or the pooled connection keeps the lock.
+			err := conn.WithContext(context.Background()).Exec("SELECT pg_advisory_unlock(?)", advisoryLockKey).Error
+			if err != nil {
+				logrus.Errorf("failed to release migration lock: %v", err)
+			}
+		}()
+
+		err = conn.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+			version bigint PRIMARY KEY,
+			name varchar(255) NOT NULL,
+			applied_at timestamptz NOT NULL
+		)`).Error
+		if err != nil {
+			return err
+		}
+		return fn(conn)
+	})
+}
+
+func applied(db *gorm.DB) (map[uint]SchemaMigration, error) {
+	var rows []SchemaMigration
+	if db.Migrator().HasTable(&SchemaMigration{}) {
+		err := db.Order("version").Find(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	byVersion := make(map[uint]SchemaMigration, len(rows))
+	for _, row := range rows {
+		byVersion[row.Version] = row
+	}
+	return byVersion, nil
+}
+
+// Up applies every pending migration in order, each in its own transaction,
+// and returns the ones it applied.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		appliedVersions, err := applied(conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := appliedVersions[migration.Version]; ok {
+				continue
+			}
+
+			err = conn.Transaction(func(tx *gorm.DB) error {
+				err := migration.Up(tx)
+				if err != nil {
+					return err
+				}
+				return tx.Create(&SchemaMigration{
+					Version:   migration.Version,
+					Name:      migration.Name,
+					AppliedAt: time.Now(),
+				}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			logrus.Infof("applied migration %d_%s", migration.Version, migration.Name)
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down rolls back the last steps applied migrations, newest first, and
+// returns the ones it rolled back.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		appliedVersions, err := applied(conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := appliedVersions[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == nil {
+				return fmt.Errorf("migration %d_%s cannot be rolled back", migration.Version, migration.Name)
+			}
+
+			err = conn.Transaction(func(tx *gorm.DB) error {
+				err := migration.Down(tx)
+				if err != nil {
+					return err
+				}
+				return tx.Delete(&SchemaMigration{}, migration.Version).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			logrus.Infof("rolled back migration %d_%s", migration.Version, migration.Name)
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Status lists every migration with the time it was applied, followed by any
+// applied versions this build does not know.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	appliedVersions, err := applied(m.db.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if row, ok := appliedVersions[migration.Version]; ok {
+			appliedAt := row.AppliedAt
+			status.AppliedAt = &appliedAt
+			delete(appliedVersions, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, row := range appliedVersions {
+		appliedAt := row.AppliedAt
+		statuses = append(statuses, MigrationStatus{
+			Version:   row.Version,
+			Name:      row.Name,
+			AppliedAt: &appliedAt,
+			Unknown:   true,
+		})
+	}
+	return statuses, nil
+}
+
+// Pending returns the migrations that have not been applied yet.
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	appliedVersions, err := applied(m.db.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if _, ok := appliedVersions[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// Create writes empty up and down SQL files for a new migration to dir, using
+// the version after the newest known migration.
+func Create(dir, name string) ([]string, error) {
+	if !namePattern.MatchString(name) {
+		return nil, errors.New("migration name may only contain lowercase letters, digits and underscores")
+	}
+
+	migrations, err := All()
+	if err != nil {
+		return nil, err
+	}
+	var latest uint
+	if len(migrations) > 0 {
+		latest = migrations[len(migrations)-1].Version
+	}
+	// Files created since the last build are not embedded yet.
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err == nil && uint(version) > latest {
+			latest = uint(version)
+		}
+	}
+	version := latest + 1
+
+	files := []string{
+		filepath.Join(dir, fmt.Sprintf("%06d_%s.up.sql", version, name)),
+		filepath.Join(dir, fmt.Sprintf("%06d_%s.down.sql", version, name)),
+	}
+	for _, file := range files {
+		f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return nil, err
+		}
+		_, err = fmt.Fprintf(f, "-- %s\n", filepath.Base(file))
+		closeErr := f.Close()
+		if err != nil {
+			return nil, err
+		}
+		if closeErr != nil {
+			return nil, closeErr
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS service_clients (
+    id bigserial PRIMARY KEY,
+    uuid uuid NOT NULL,
+    name varchar(100) NOT NULL,
+    secret varchar(255) NOT NULL,
+    previous_secret varchar(255),
+    previous_secret_expires_at timestamptz,
+    scopes text NOT NULL DEFAULT '[]',
+    enabled boolean NOT NULL DEFAULT true,
+    last_used_at timestamptz,
+    revoked_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_service_clients_uuid ON service_clients (uuid);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_service_clients_name ON service_clients (name);
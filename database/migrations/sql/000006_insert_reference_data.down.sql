@@ -0,0 +1,4 @@
+-- Roles are kept: users reference them and deleting a role cascades to its
+-- users.
+DELETE FROM permissions
+WHERE code IN ('user:read', 'user:update', 'user:list', 'user:manage', 'service_client:manage');
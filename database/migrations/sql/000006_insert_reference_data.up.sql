@@ -0,0 +1,34 @@
+-- Roles and permissions the service cannot work without: Register assigns the
+-- CUSTOMER role and RequirePermission routes look the grants up. The seeders
+-- insert the same rows, so either may run first.
+
+INSERT INTO roles (code, name, created_at, updated_at)
+SELECT 'ADMIN', 'Administrator', now(), now()
+WHERE NOT EXISTS (SELECT 1 FROM roles WHERE code = 'ADMIN');
+
+INSERT INTO roles (code, name, created_at, updated_at)
+SELECT 'CUSTOMER', 'Customer', now(), now()
+WHERE NOT EXISTS (SELECT 1 FROM roles WHERE code = 'CUSTOMER');
+
+INSERT INTO permissions (code, name, created_at, updated_at) VALUES
+    ('user:read', 'Read user', now(), now()),
+    ('user:update', 'Update user', now(), now()),
+    ('user:list', 'List users', now(), now()),
+    ('user:manage', 'Manage users', now(), now()),
+    ('service_client:manage', 'Manage service clients', now(), now())
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT roles.id, permissions.id
+FROM (VALUES
+    ('ADMIN', 'user:read'),
+    ('ADMIN', 'user:update'),
+    ('ADMIN', 'user:list'),
+    ('ADMIN', 'user:manage'),
+    ('ADMIN', 'service_client:manage'),
+    ('CUSTOMER', 'user:read'),
+    ('CUSTOMER', 'user:update')
+) AS grants (role_code, permission_code)
+JOIN roles ON roles.code = grants.role_code
+JOIN permissions ON permissions.code = grants.permission_code
+ON CONFLICT DO NOTHING;
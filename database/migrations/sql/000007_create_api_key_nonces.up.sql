@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS api_key_nonces (
+    id bigserial PRIMARY KEY,
+    service_name varchar(100) NOT NULL,
+    nonce varchar(128) NOT NULL,
+    expires_at timestamptz NOT NULL,
+    created_at timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_key_nonces_service_nonce ON api_key_nonces (service_name, nonce);
+CREATE INDEX IF NOT EXISTS idx_api_key_nonces_expires_at ON api_key_nonces (expires_at);
@@ -0,0 +1,166 @@
+package seeders
+
+import (
+	"fmt"
+	"os"
+	"time"
+	"user-service/common/util"
+	"user-service/config"
+	"user-service/constants"
+	"user-service/domain/models"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	bootstrapPasswordLength = 20
+	defaultPasswordFile     = "bootstrap-admin-password"
+
+	// legacyAdminUsername and legacyAdminPassword are the credentials the user
+	// seeder used to create in every environment.
+	legacyAdminUsername = "admin"
+	legacyAdminPassword = "admin123"
+)
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// RunAdminSeeder creates the first admin when there is none yet. Credentials
+// come from the BOOTSTRAP_ADMIN_* environment variables or bootstrapAdmin in
+// the config; without a password one is generated and written to the password
+// file, readable only by its owner. The admin has to change the password on
+// first login.
+func RunAdminSeeder(db *gorm.DB) {
+	expireLegacyAdminPassword(db)
+
+	var admins int64
+	err := db.Model(&models.User{}).
+		Joins("JOIN roles ON roles.id = users.role_id").
+		Where("LOWER(roles.code) = ?", constants.RoleAdmin).
+		Count(&admins).Error
+	if err != nil {
+		logrus.Errorf("failed to count admins: %v", err)
+		panic(err)
+	}
+	if admins > 0 {
+		logrus.Infof("admin already exists, skipping bootstrap admin")
+		return
+	}
+
+	cfg := config.Config.BootstrapAdmin
+	username := envOr("BOOTSTRAP_ADMIN_USERNAME", cfg.Username)
+	email := envOr("BOOTSTRAP_ADMIN_EMAIL", cfg.Email)
+	password := envOr("BOOTSTRAP_ADMIN_PASSWORD", cfg.Password)
+	if username == "" || email == "" {
+		logrus.Warnf("bootstrap admin username or email is not configured, no admin was created")
+		return
+	}
+
+	generated := password == ""
+	if generated {
+		password, err = util.GeneratePassword(bootstrapPasswordLength)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	var role models.Role
+	err = db.Where("LOWER(code) = ?", constants.RoleAdmin).First(&role).Error
+	if err != nil {
+		logrus.Errorf("failed to find admin role: %v", err)
+		panic(err)
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		panic(err)
+	}
+
+	passwordFile := envOr("BOOTSTRAP_ADMIN_PASSWORD_FILE", cfg.PasswordFile)
+	if passwordFile == "" {
+		passwordFile = defaultPasswordFile
+	}
+	if generated {
+		err = writePasswordFile(passwordFile, password)
+		if err != nil {
+			logrus.Errorf("failed to write bootstrap admin password to %s: %v", passwordFile, err)
+			panic(err)
+		}
+	}
+
+	now := time.Now()
+	user := models.User{
+		UUID:               uuid.New(),
+		Name:               envOr("BOOTSTRAP_ADMIN_NAME", cfg.Name),
+		UserName:           util.NormalizeIdentifier(username),
+		Password:           string(hashedPassword),
+		PhoneNumber:        envOr("BOOTSTRAP_ADMIN_PHONE_NUMBER", cfg.PhoneNumber),
+		Email:              util.NormalizeIdentifier(email),
+		RoleID:             role.ID,
+		Status:             constants.UserStatusActive,
+		EmailVerifiedAt:    &now,
+		MustChangePassword: true,
+	}
+	err = db.Create(&user).Error
+	if err != nil {
+		if generated {
+			_ = os.Remove(passwordFile)
+		}
+		logrus.Errorf("failed to seed bootstrap admin: %v", err)
+		panic(err)
+	}
+
+	logrus.Infof("bootstrap admin %s seeded successfully", user.UserName)
+	if generated {
+		logrus.Warnf("bootstrap admin password was written to %s; delete the file once the password has been changed", passwordFile)
+	}
+}
+
+// writePasswordFile creates the file with owner-only permissions. An existing
+// file is never overwritten, so a password from an earlier run is not lost.
+func writePasswordFile(path, password string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(file, password)
+	if err != nil {
+		_ = file.Close()
+		_ = os.Remove(path)
+		return err
+	}
+	return file.Close()
+}
+
+// expireLegacyAdminPassword makes the admin created by earlier releases with
+// the well-known password change it before any other authenticated route
+// accepts its token.
+func expireLegacyAdminPassword(db *gorm.DB) {
+	var user models.User
+	err := db.Where("user_name = ?", legacyAdminUsername).Limit(1).Find(&user).Error
+	if err != nil {
+		logrus.Errorf("failed to look up the %s user: %v", legacyAdminUsername, err)
+		panic(err)
+	}
+	if user.ID == 0 || user.MustChangePassword {
+		return
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(legacyAdminPassword)) != nil {
+		return
+	}
+
+	err = db.Model(&user).Update("must_change_password", true).Error
+	if err != nil {
+		logrus.Errorf("failed to expire the default password of %s: %v", legacyAdminUsername, err)
+		panic(err)
+	}
+	logrus.Warnf("user %s still had the default password; it must be changed on the next login", legacyAdminUsername)
+}
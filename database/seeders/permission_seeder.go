@@ -0,0 +1,80 @@
+package seeders
+
+import (
+	"user-service/constants"
+	"user-service/domain/models"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+func RunPermissionSeeder(db *gorm.DB) {
+	permissions := []models.Permission{
+		{
+			Code: constants.PermissionUserRead,
+			Name: "Read user",
+		},
+		{
+			Code: constants.PermissionUserUpdate,
+			Name: "Update user",
+		},
+		{
+			Code: constants.PermissionUserList,
+			Name: "List users",
+		},
+		{
+			Code: constants.PermissionUserManage,
+			Name: "Manage users",
+		},
+		{
+			Code: constants.PermissionServiceClientManage,
+			Name: "Manage service clients",
+		},
+	}
+
+	for i := range permissions {
+		err := db.FirstOrCreate(&permissions[i], models.Permission{Code: permissions[i].Code}).Error
+		if err != nil {
+			logrus.Errorf("failed to seed permission: %v", err)
+			panic(err)
+		}
+		logrus.Infof("permission %s seeded successfully", permissions[i].Code)
+	}
+
+	rolePermissions := map[string][]string{
+		"ADMIN": {
+			constants.PermissionUserRead,
+			constants.PermissionUserUpdate,
+			constants.PermissionUserList,
+			constants.PermissionUserManage,
+			constants.PermissionServiceClientManage,
+		},
+		"CUSTOMER": {
+			constants.PermissionUserRead,
+			constants.PermissionUserUpdate,
+		},
+	}
+
+	for code, permissionCodes := range rolePermissions {
+		var role models.Role
+		err := db.Where("code = ?", code).First(&role).Error
+		if err != nil {
+			logrus.Errorf("failed to find role %s: %v", code, err)
+			panic(err)
+		}
+
+		var granted []models.Permission
+		err = db.Where("code IN ?", permissionCodes).Find(&granted).Error
+		if err != nil {
+			logrus.Errorf("failed to find permissions: %v", err)
+			panic(err)
+		}
+
+		err = db.Model(&role).Association("Permissions").Append(granted)
+		if err != nil {
+			logrus.Errorf("failed to seed role permissions: %v", err)
+			panic(err)
+		}
+		logrus.Infof("permissions for role %s seeded successfully", code)
+	}
+}
@@ -1,6 +1,11 @@
 package seeders
 
-import "gorm.io/gorm"
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
 
 type Registry struct {
 	db *gorm.DB
@@ -8,6 +13,30 @@ type Registry struct {
 
 type ISeederRegistry interface {
 	Run()
+	RunOnly(...string) error
+}
+
+type seeder struct {
+	name string
+	run  func(*gorm.DB)
+}
+
+// seeders lists every seeder in the order they have to run, since later ones
+// depend on data from earlier ones.
+var seeders = []seeder{
+	{name: "role", run: RunRoleSeeder},
+	{name: "permission", run: RunPermissionSeeder},
+	{name: "admin", run: RunAdminSeeder},
+	{name: "user", run: RunUserSeeder},
+}
+
+// Names returns the names accepted by RunOnly.
+func Names() []string {
+	names := make([]string, 0, len(seeders))
+	for _, s := range seeders {
+		names = append(names, s.name)
+	}
+	return names
 }
 
 func NewSeederRegistry(db *gorm.DB) ISeederRegistry {
@@ -16,6 +45,31 @@ func NewSeederRegistry(db *gorm.DB) ISeederRegistry {
 
 func (s *Registry) Run() {
 	// Run all seeders here
-	RunRoleSeeder(s.db)
-	RunUserSeeder(s.db)
+	for _, seeder := range seeders {
+		seeder.run(s.db)
+	}
+}
+
+// RunOnly runs the named seeders, still in dependency order.
+func (s *Registry) RunOnly(names ...string) error {
+	known := make(map[string]bool, len(seeders))
+	for _, seeder := range seeders {
+		known[seeder.name] = true
+	}
+
+	selected := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !known[name] {
+			return fmt.Errorf("unknown seeder %q, expected one of %s", name, strings.Join(Names(), ", "))
+		}
+		selected[name] = true
+	}
+
+	for _, seeder := range seeders {
+		if selected[seeder.name] {
+			seeder.run(s.db)
+		}
+	}
+	return nil
 }
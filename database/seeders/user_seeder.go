@@ -1,6 +1,8 @@
 package seeders
 
 import (
+	"time"
+	"user-service/config"
 	"user-service/constants"
 	"user-service/domain/models"
 
@@ -10,16 +12,26 @@ import (
 	"gorm.io/gorm"
 )
 
+// RunUserSeeder creates demo accounts with well-known passwords, so it only
+// runs in local and development environments.
 func RunUserSeeder(db *gorm.DB) {
-	password, _ := bcrypt.GenerateFromPassword([]byte("admin123"), bcrypt.DefaultCost)
+	if !config.IsDevelopment() {
+		logrus.Infof("skipping demo users in %q environment", config.Config.AppEnv)
+		return
+	}
+
+	password, _ := bcrypt.GenerateFromPassword([]byte("customer123"), bcrypt.DefaultCost)
+	now := time.Now()
 	user := models.User{
-		UUID:        uuid.New(),
-		Name:        "Administrator",
-		UserName:    "admin",
-		Password:    string(password),
-		PhoneNumber: "081234567890",
-		Email:       "admin.gmail.com",
-		RoleID:      constants.Admin,
+		UUID:            uuid.New(),
+		Name:            "Demo Customer",
+		UserName:        "customer",
+		Password:        string(password),
+		PhoneNumber:     "081234567890",
+		Email:           "customer@example.com",
+		RoleID:          constants.Customer,
+		Status:          constants.UserStatusActive,
+		EmailVerifiedAt: &now,
 	}
 
 	err := db.FirstOrCreate(&user, models.User{UserName: user.UserName}).Error
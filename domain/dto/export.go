@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserDataExport is the archive returned by GET /users/me/export with
+// everything stored about the requesting user.
+type UserDataExport struct {
+	ExportedAt   time.Time           `json:"exportedAt"`
+	Profile      UserExportProfile   `json:"profile"`
+	Role         UserExportRole      `json:"role"`
+	MFA          UserExportMFA       `json:"mfa"`
+	Sessions     []UserExportSession `json:"sessions"`
+	LoginHistory []UserExportAudit   `json:"loginHistory"`
+	AuditEntries []UserExportAudit   `json:"auditEntries"`
+}
+
+type UserExportProfile struct {
+	UUID            uuid.UUID  `json:"uuid"`
+	Name            string     `json:"name"`
+	UserName        string     `json:"username"`
+	Email           string     `json:"email"`
+	PhoneNumber     string     `json:"phoneNumber"`
+	Status          string     `json:"status"`
+	EmailVerifiedAt *time.Time `json:"emailVerifiedAt"`
+	CreatedAt       *time.Time `json:"createdAt"`
+	UpdatedAt       *time.Time `json:"updatedAt"`
+}
+
+type UserExportRole struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+type UserExportMFA struct {
+	Enabled   bool       `json:"enabled"`
+	EnabledAt *time.Time `json:"enabledAt"`
+}
+
+type UserExportSession struct {
+	CreatedAt *time.Time `json:"createdAt"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+	RevokedAt *time.Time `json:"revokedAt"`
+}
+
+type UserExportAudit struct {
+	Action     string     `json:"action"`
+	Outcome    string     `json:"outcome"`
+	ActorUUID  *uuid.UUID `json:"actorUuid"`
+	TargetUUID *uuid.UUID `json:"targetUuid"`
+	Reason     string     `json:"reason,omitempty"`
+	IPAddress  string     `json:"ipAddress,omitempty"`
+	CreatedAt  *time.Time `json:"createdAt"`
+}
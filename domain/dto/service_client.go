@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ServiceClientRequest struct {
+	Name   string   `json:"name" validate:"required,max=100,excludesall= :"`
+	Scopes []string `json:"scopes" validate:"required,min=1,dive,required"`
+}
+
+type RotateServiceClientRequest struct {
+	// OverlapMinutes is how long the old secret keeps working. Nil uses the
+	// configured default and zero revokes it at once.
+	OverlapMinutes *int `json:"overlapMinutes" validate:"omitempty,min=0"`
+}
+
+type ServiceClientResponse struct {
+	UUID                    uuid.UUID  `json:"uuid"`
+	Name                    string     `json:"name"`
+	Scopes                  []string   `json:"scopes"`
+	Enabled                 bool       `json:"enabled"`
+	PreviousSecretExpiresAt *time.Time `json:"previousSecretExpiresAt,omitempty"`
+	LastUsedAt              *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt               *time.Time `json:"revokedAt,omitempty"`
+	CreatedAt               *time.Time `json:"createdAt"`
+}
+
+// ServiceClientSecretResponse is returned when a secret is issued or rotated.
+// The secret is not shown again.
+type ServiceClientSecretResponse struct {
+	ServiceClientResponse
+	Secret string `json:"secret"`
+}
+
+// ServicePrincipal is the service that authenticated a request with its API
+// key.
+type ServicePrincipal struct {
+	UUID   uuid.UUID
+	Name   string
+	Scopes []string
+}
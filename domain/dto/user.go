@@ -1,24 +1,47 @@
 package dto
 
-import "github.com/google/uuid"
+import (
+	"time"
 
+	"github.com/google/uuid"
+)
+
+// LoginRequest identifies the account by Identifier, which may be a username,
+// an email address or, when enabled, a phone number. Username is still
+// accepted for older clients.
 type LoginRequest struct {
-	Username string `json:"username" validate:"required"`
-	Password string `json:"password" validate:"required"`
+	Identifier string `json:"identifier" validate:"required_without=Username"`
+	Username   string `json:"username" validate:"required_without=Identifier"`
+	Password   string `json:"password" validate:"required"`
+	IPAddress  string `json:"-"`
 }
 
 type UserResponse struct {
-	UUID        uuid.UUID `json:"uuid"`
-	Name        string    `json:"name"`
-	UserName    string    `json:"username"`
-	Email       string    `json:"email"`
-	Role        string    `json:"role"`
-	PhoneNumber string    `json:"phone_number"`
+	UUID               uuid.UUID `json:"uuid"`
+	Name               string    `json:"name"`
+	UserName           string    `json:"username"`
+	Email              string    `json:"email"`
+	Role               string    `json:"role"`
+	PhoneNumber        string    `json:"phone_number"`
+	EmailVerified      bool      `json:"email_verified"`
+	Status             string    `json:"status,omitempty"`
+	MustChangePassword bool      `json:"must_change_password,omitempty"`
 }
 
 type LoginResponse struct {
-	User  UserResponse `json:"user"`
-	Token string       `json:"token"`
+	User         UserResponse `json:"user"`
+	Token        string       `json:"token"`
+	RefreshToken string       `json:"refreshToken"`
+	MFARequired  bool         `json:"mfaRequired,omitempty"`
+	MFAToken     string       `json:"mfaToken,omitempty"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken" validate:"required"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refreshToken"`
 }
 
 type RegisterRequest struct {
@@ -26,7 +49,7 @@ type RegisterRequest struct {
 	Email           string `json:"email" validate:"required,email"`
 	PhoneNumber     string `json:"phoneNumber" validate:"required"`
 	Password        string `json:"password" validate:"required"`
-	UserName        string `json:"username" validate:"required"`
+	UserName        string `json:"username" validate:"required,excludes=@"`
 	ConfirmPassword string `json:"confirmPassword" validate:"required"`
 	RoleID          uint
 }
@@ -37,10 +60,73 @@ type RegisterResponse struct {
 
 type UpdateRequest struct {
 	Name            string  `json:"name" validate:"required"`
-	Username        string  `json:"username" validate:"required"`
+	Username        string  `json:"username" validate:"required,excludes=@"`
 	Password        *string `json:"password,omitempty"`
-	ConfirmPassword *string `json:"confirmPassword,omitempty"`
+	ConfirmPassword *string `json:"confirmPassword,omitempty" validate:"required_with=Password"`
 	Email           string  `json:"email" validate:"required,email"`
 	PhoneNumber     string  `json:"phoneNumber" validate:"required"`
 	RoleID          uint
 }
+
+type UserListRequest struct {
+	Page        int       `form:"page" validate:"omitempty,min=1"`
+	Limit       int       `form:"limit" validate:"omitempty,min=1,max=100"`
+	Cursor      string    `form:"cursor"`
+	Search      string    `form:"search"`
+	Role        string    `form:"role"`
+	Status      string    `form:"status" validate:"omitempty,oneof=active suspended deactivated"`
+	CreatedFrom time.Time `form:"createdFrom" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo   time.Time `form:"createdTo" time_format:"2006-01-02T15:04:05Z07:00"`
+	SortBy      string    `form:"sortBy" validate:"omitempty,oneof=name username email createdAt"`
+	SortOrder   string    `form:"sortOrder" validate:"omitempty,oneof=asc desc"`
+}
+
+// UserCursor is the decoded form of UserListRequest.Cursor: the sort value and
+// ID of the last user on the previous page.
+type UserCursor struct {
+	Value string `json:"v"`
+	ID    uint   `json:"id"`
+}
+
+type UserListResponse struct {
+	Users      []UserResponse
+	Pagination Pagination
+}
+
+type Pagination struct {
+	Page       int    `json:"page,omitempty"`
+	Limit      int    `json:"limit"`
+	TotalData  int64  `json:"totalData"`
+	TotalPage  int    `json:"totalPage,omitempty"`
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+type ForgotPasswordRequest struct {
+	Email     string `json:"email" validate:"required,email"`
+	IPAddress string `json:"-"`
+}
+
+type ResetPasswordRequest struct {
+	Token           string `json:"token" validate:"required"`
+	Password        string `json:"password" validate:"required"`
+	ConfirmPassword string `json:"confirmPassword" validate:"required"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" form:"token" validate:"required"`
+}
+
+type ResendEmailVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type CloseAccountRequest struct {
+	Password string `json:"password" validate:"required"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword" validate:"required"`
+	Password        string `json:"password" validate:"required"`
+	ConfirmPassword string `json:"confirmPassword" validate:"required"`
+	IPAddress       string `json:"-"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// APIKeyNonce is the nonce of an accepted signed request, kept until the
+// request's timestamp can no longer pass the clock skew check. It is stored in
+// the database so that every replica rejects a replay.
+type APIKeyNonce struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement"`
+	ServiceName string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_api_key_nonces_service_nonce"`
+	Nonce       string    `gorm:"type:varchar(128);not null;uniqueIndex:idx_api_key_nonces_service_nonce"`
+	ExpiresAt   time.Time `gorm:"not null;index"`
+	CreatedAt   *time.Time
+}
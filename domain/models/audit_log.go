@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditLog struct {
+	ID         uint       `gorm:"primaryKey;autoIncrement"`
+	ActorUUID  *uuid.UUID `gorm:"type:uuid;index"`
+	Action     string     `gorm:"type:varchar(100);not null;index"`
+	TargetUUID *uuid.UUID `gorm:"type:uuid;index"`
+	Outcome    string     `gorm:"type:varchar(20);not null"`
+	Reason     string     `gorm:"type:varchar(255)"`
+	IPAddress  string     `gorm:"type:varchar(45)"`
+	CreatedAt  *time.Time
+}
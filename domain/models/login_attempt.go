@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// LoginAttempt counts recent failed logins for one throttling key, either an
+// account identifier or a client IP.
+type LoginAttempt struct {
+	ID           uint   `gorm:"primaryKey;autoIncrement"`
+	Key          string `gorm:"type:varchar(255);not null;uniqueIndex"`
+	FailedCount  int    `gorm:"not null;default:0"`
+	LastFailedAt *time.Time
+	LockedUntil  *time.Time
+	CreatedAt    *time.Time
+	UpdatedAt    *time.Time
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// UserMFA holds a user's TOTP seed, encrypted at rest. The factor only counts
+// once EnabledAt is set, after the user confirmed a first code.
+type UserMFA struct {
+	ID           uint   `gorm:"primaryKey;autoIncrement"`
+	UserID       uint   `gorm:"not null;uniqueIndex"`
+	Secret       string `gorm:"type:varchar(255);not null"`
+	EnabledAt    *time.Time
+	LastUsedStep int64 `gorm:"not null;default:0"`
+	CreatedAt    *time.Time
+	UpdatedAt    *time.Time
+	User         User `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+type RecoveryCode struct {
+	ID        uint   `gorm:"primaryKey;autoIncrement"`
+	UserID    uint   `gorm:"not null;index"`
+	CodeHash  string `gorm:"type:varchar(64);not null"`
+	UsedAt    *time.Time
+	CreatedAt *time.Time
+	User      User `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
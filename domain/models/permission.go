@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type Permission struct {
+	ID        uint   `gorm:"primaryKey;autoIncrement"`
+	Code      string `gorm:"type:varchar(50);not null;uniqueIndex"`
+	Name      string `gorm:"type:varchar(100);not null"`
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+}
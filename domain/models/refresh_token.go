@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RefreshToken struct {
+	ID           uint      `gorm:"primaryKey;autoIncrement"`
+	UserID       uint      `gorm:"not null;index"`
+	FamilyID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	TokenHash    string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt    time.Time `gorm:"not null"`
+	RevokedAt    *time.Time
+	ReplacedByID *uint
+	CreatedAt    *time.Time
+	UpdatedAt    *time.Time
+	User         User `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RevokedToken struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	JTI       string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	UserUUID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	CreatedAt *time.Time
+}
+
+// UserTokenRevocation invalidates every token of a user issued before RevokedAt.
+type UserTokenRevocation struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	UserUUID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	RevokedAt time.Time `gorm:"not null"`
+	CreatedAt *time.Time
+	UpdatedAt *time.Time
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ServiceClient is a backend service allowed to call this one. Its secrets are
+// encrypted at rest because the service needs the plaintext to check HMAC
+// signatures. PreviousSecret keeps working until PreviousSecretExpiresAt, so
+// a caller can switch to a rotated secret without downtime.
+type ServiceClient struct {
+	ID                      uint      `gorm:"primaryKey;autoIncrement"`
+	UUID                    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_service_clients_uuid"`
+	Name                    string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_service_clients_name"`
+	Secret                  string    `gorm:"type:varchar(255);not null"`
+	PreviousSecret          *string   `gorm:"type:varchar(255)"`
+	PreviousSecretExpiresAt *time.Time
+	Scopes                  []string `gorm:"type:text;not null;serializer:json"`
+	Enabled                 bool     `gorm:"not null;default:true"`
+	LastUsedAt              *time.Time
+	RevokedAt               *time.Time
+	CreatedAt               *time.Time
+	UpdatedAt               *time.Time
+}
+
+const ServiceClientNameIndex = "idx_service_clients_name"
@@ -4,18 +4,32 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type User struct {
-	ID          uint      `gorm:"primaryKey;autoIncrement"`
-	UUID        uuid.UUID `gorm:"type:uuid;not null"`
-	Name        string    `gorm:"type:varchar(100);not null"`
-	UserName    string    `gorm:"type:varchar(20);not null"`
-	Password    string    `gorm:"type:varchar(255);not null"`
-	PhoneNumber string    `gorm:"type:varchar(15);not null"`
-	Email       string    `gorm:"type:varchar(100);not null"`
-	RoleID      uint      `gorm:"type:uint;not null"`
-	CreatedAt   *time.Time
-	UpdatedAt   *time.Time
-	Role        Role `gorm:"foreignKey:RoleID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	ID                 uint      `gorm:"primaryKey;autoIncrement"`
+	UUID               uuid.UUID `gorm:"type:uuid;not null"`
+	Name               string    `gorm:"type:varchar(100);not null"`
+	UserName           string    `gorm:"type:varchar(20);not null"`
+	Password           string    `gorm:"type:varchar(255);not null"`
+	PhoneNumber        string    `gorm:"type:varchar(15);not null"`
+	Email              string    `gorm:"type:varchar(100);not null"`
+	RoleID             uint      `gorm:"type:uint;not null"`
+	Status             string    `gorm:"type:varchar(20);not null;default:active"`
+	MustChangePassword bool      `gorm:"not null;default:false"`
+	EmailVerifiedAt    *time.Time
+	ErasedAt           *time.Time
+	CreatedAt          *time.Time
+	UpdatedAt          *time.Time
+	DeletedAt          gorm.DeletedAt `gorm:"index"`
+	Role               Role           `gorm:"foreignKey:RoleID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
+
+// Unique indexes on users. They are created by the migrations package rather
+// than struct tags because existing duplicates have to be resolved first.
+const (
+	UserUUIDIndex     = "idx_users_uuid"
+	UserUserNameIndex = "idx_users_user_name_lower"
+	UserEmailIndex    = "idx_users_email_lower"
+)
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// UserToken is a hashed, single-use token sent to a user out of band, such as
+// a password reset link.
+type UserToken struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement"`
+	UserID    uint      `gorm:"not null;index"`
+	Purpose   string    `gorm:"type:varchar(30);not null"`
+	TokenHash string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt *time.Time
+	User      User `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
@@ -0,0 +1,76 @@
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+	"user-service/common/requestid"
+	"user-service/common/response"
+	"user-service/constants"
+	errConstant "user-service/constants/error"
+	"user-service/domain/dto"
+	"user-service/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+func responseForbidden(c *gin.Context) {
+	c.JSON(http.StatusForbidden, response.Response{
+		Status:    constants.Error,
+		Message:   errConstant.ErrForbidden.Error(),
+		RequestID: requestid.FromContext(c.Request.Context()),
+	})
+	c.Abort()
+}
+
+func userFromContext(c *gin.Context) (*dto.UserResponse, bool) {
+	user, ok := c.Request.Context().Value(constants.UserLogin).(*dto.UserResponse)
+	return user, ok && user != nil
+}
+
+// RequireRole allows the request when the authenticated user has one of the
+// given role codes. It must run after Authenticate.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := userFromContext(c)
+		if !ok {
+			responseUnauthorized(c, errConstant.ErrUnauthorized.Error())
+			return
+		}
+
+		for _, role := range roles {
+			if strings.EqualFold(user.Role, role) {
+				c.Next()
+				return
+			}
+		}
+		responseForbidden(c)
+	}
+}
+
+// RequirePermission allows the request when the role of the authenticated user
+// is granted every given permission. It must run after Authenticate.
+func RequirePermission(service services.IServiceRegistry, permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := userFromContext(c)
+		if !ok {
+			responseUnauthorized(c, errConstant.ErrUnauthorized.Error())
+			return
+		}
+
+		allowed, err := service.GetPermission().HasPermissions(c.Request.Context(), user.Role, permissions...)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, response.Response{
+				Status:    constants.Error,
+				Message:   errConstant.ErrInternalServerError.Error(),
+				RequestID: requestid.FromContext(c.Request.Context()),
+			})
+			c.Abort()
+			return
+		}
+		if !allowed {
+			responseForbidden(c)
+			return
+		}
+		c.Next()
+	}
+}
@@ -6,17 +6,27 @@
 package middlewares
 
 import (
+	"bytes"
 	"context"
-	"crypto/sha256"
-	"encoding/hex"
-	"fmt"
+	"errors"
+	"io"
 	"net/http"
+	"runtime/debug"
+	"strconv"
 	"strings"
+	"time"
+	"user-service/common/apikey"
+	"user-service/common/jwk"
+	"user-service/common/logger"
+	"user-service/common/metrics"
+	"user-service/common/requestid"
 	"user-service/common/response"
-	"user-service/config"
+	"user-service/common/tracing"
 	"user-service/constants"
 	errConstant "user-service/constants/error"
-	services "user-service/services/user"
+	"user-service/domain/dto"
+	"user-service/services"
+	userServices "user-service/services/user"
 
 	"github.com/didip/tollbooth"
 	"github.com/didip/tollbooth/limiter"
@@ -26,14 +36,20 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// maxSignedBodyBytes bounds the body read to check an API key signature.
+const maxSignedBodyBytes = 1 << 20
+
 func HandlePanic() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
 			if r := recover(); r != nil {
-				logrus.Errorf("Recovered from panic: %v", r)
+				logger.FromContext(c.Request.Context()).
+					WithField("stack", string(debug.Stack())).
+					Errorf("Recovered from panic: %v", r)
 				c.JSON(http.StatusInternalServerError, response.Response{
-					Status:  constants.Error,
-					Message: errConstant.ErrInternalServerError,
+					Status:    constants.Error,
+					Message:   errConstant.ErrInternalServerError,
+					RequestID: requestid.FromContext(c.Request.Context()),
 				})
 
 				c.Abort()
@@ -43,14 +59,86 @@ func HandlePanic() gin.HandlerFunc {
 	}
 }
 
+// RequestID reuses the x-request-id sent by the caller when it is well formed
+// and generates one otherwise. The ID is put in the request context, echoed
+// in the response headers and added to the response envelope and log lines.
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(constants.XRequestID)
+		if !requestid.Valid(id) {
+			id = requestid.New()
+		}
+
+		c.Request.Header.Set(constants.XRequestID, id)
+		c.Header(constants.XRequestID, id)
+		c.Request = c.Request.WithContext(requestid.WithContext(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+// RequestLogger puts a logger carrying the request ID and route of the
+// request in its context and writes one access log line when the request is done. Later
+// middlewares add the user or service that made the request.
+func RequestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		entry := logrus.WithFields(logrus.Fields{
+			"request_id": requestid.FromContext(c.Request.Context()),
+			"trace_id":   tracing.TraceID(c.Request.Context()),
+			"method":     c.Request.Method,
+			"route":      c.FullPath(),
+			"client_ip":  c.ClientIP(),
+		})
+		c.Request = c.Request.WithContext(logger.WithContext(c.Request.Context(), entry))
+
+		c.Next()
+
+		status := c.Writer.Status()
+		access := logger.FromContext(c.Request.Context()).WithFields(logrus.Fields{
+			"path":       c.Request.URL.Path,
+			"status":     status,
+			"latency_ms": time.Since(start).Milliseconds(),
+			"bytes":      c.Writer.Size(),
+		})
+		switch {
+		case status >= http.StatusInternalServerError:
+			access.Error("request completed")
+		case status >= http.StatusBadRequest:
+			access.Warn("request completed")
+		default:
+			access.Info("request completed")
+		}
+	}
+}
+
+// Metrics counts requests and observes their latency per method, route and
+// status. Requests that match no route share one label so that scanners
+// cannot create new series.
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		status := strconv.Itoa(c.Writer.Status())
+		metrics.HTTPRequests.WithLabelValues(c.Request.Method, route, status).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
+
 // rate limiter berfungsi untuk memberi batasan req yang masuk ke session
 func RateLimiter(lmt *limiter.Limiter) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		err := tollbooth.LimitByRequest(lmt, c.Writer, c.Request)
 		if err != nil {
+			metrics.RateLimitRejections.WithLabelValues(metrics.LimiterRequest).Inc()
 			c.JSON(http.StatusTooManyRequests, response.Response{
-				Status:  constants.Error,
-				Message: errConstant.ErrTooManyRequests.Error(),
+				Status:    constants.Error,
+				Message:   errConstant.ErrTooManyRequests.Error(),
+				RequestID: requestid.FromContext(c.Request.Context()),
 			})
 			c.Abort()
 			return
@@ -70,109 +158,235 @@ func extractBearerToken(token string) string {
 
 func responseUnauthorized(c *gin.Context, message string) {
 	c.JSON(http.StatusUnauthorized, response.Response{
-		Status:  constants.Error,
-		Message: message,
+		Status:    constants.Error,
+		Message:   message,
+		RequestID: requestid.FromContext(c.Request.Context()),
 	})
 	c.Abort()
 }
 
-func validateAPIKey(c *gin.Context) error {
-	apiKey := c.GetHeader(constants.XApiKey)
-	requestAt := c.GetHeader(constants.XRequestAt)
-	serviceName := c.GetHeader(constants.XserviceName)
-	signatureKey := config.Config.SignatureKey
-
-	raw := fmt.Sprintf("%s:%s:%s", serviceName, signatureKey, requestAt)
-	hash := sha256.New()
-	hash.Write([]byte(raw))
-	resultHash := hex.EncodeToString(hash.Sum(nil))
-
-	fmt.Println("====== DEBUG API KEY VALIDATION ======")
-	fmt.Println("x-api-key        :", apiKey)
-	fmt.Println("x-request-at     :", requestAt)
-	fmt.Println("x-service-name   :", serviceName)
-	fmt.Println("signatureKey     :", signatureKey)
-	fmt.Println("Raw string       :", raw)
-	fmt.Println("Expected hash    :", resultHash)
-	fmt.Println("Match?           :", apiKey == resultHash)
-	fmt.Println("======================================")
-	fmt.Println("🔍 [DEBUG] generated hash :", resultHash)
-
-	if apiKey != resultHash {
-		fmt.Println("❌ [ERROR] API Key tidak valid")
-		return errConstant.ErrUnauthorized
+// validateAPIKey checks the x-api-key signature over the method, path,
+// timestamp, body and nonce of the request with the calling service's own
+// secret, and puts the calling service in the context. The body is read and
+// put back for the handler. Since this happens before the caller is known,
+// bodies over maxSignedBodyBytes are rejected rather than buffered.
+func validateAPIKey(c *gin.Context, service services.IServiceRegistry) error {
+	var body []byte
+	if c.Request.Body != nil {
+		var err error
+		body, err = io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxSignedBodyBytes))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return errConstant.ErrRequestTooLarge
+			}
+			return errConstant.ErrInvalidAPIKey
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	request := &apikey.Request{
+		ServiceName: c.GetHeader(constants.XserviceName),
+		Method:      c.Request.Method,
+		Path:        c.Request.URL.RequestURI(),
+		RequestAt:   c.GetHeader(constants.XRequestAt),
+		BodyHash:    apikey.HashBody(body),
+		Nonce:       c.GetHeader(constants.XNonce),
 	}
-	fmt.Println("✅ [INFO] API Key valid")
+
+	client, err := service.GetAPIKey().Verify(c.Request.Context(), request, c.GetHeader(constants.XApiKey), c.FullPath())
+	if err != nil {
+		return err
+	}
+
+	ctx := context.WithValue(c.Request.Context(), constants.ServiceLogin, &dto.ServicePrincipal{
+		UUID:   client.UUID,
+		Name:   client.Name,
+		Scopes: client.Scopes,
+	})
+	ctx = logger.WithFields(ctx, logrus.Fields{"service": client.Name})
+	c.Request = c.Request.WithContext(ctx)
 	return nil
 }
 
-func validateBearerToken(c *gin.Context, token string) error {
+func validateBearerToken(c *gin.Context, token string, service services.IServiceRegistry) error {
 	if !strings.Contains(token, "Bearer") {
-		fmt.Println("❌ [ERROR] Authorization header tidak mengandung Bearer")
+		metrics.JWTFailures.WithLabelValues(metrics.JWTMalformed).Inc()
 		return errConstant.ErrUnauthorized
 	}
 
 	tokenString := extractBearerToken(token)
 	if tokenString == "" {
-		fmt.Println("❌ [ERROR] Bearer token kosong")
+		metrics.JWTFailures.WithLabelValues(metrics.JWTMalformed).Inc()
 		return errConstant.ErrUnauthorized
 	}
 
-	claims := &services.Claims{}
-	tokenJwt, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		_, oke := token.Method.(*jwt.SigningMethodHMAC)
-		if !oke {
-			fmt.Println("❌ [ERROR] JWT method tidak sesuai")
-			return nil, errConstant.ErrInvalidToken
-		}
-
-		jwtSecret := []byte(config.Config.JwtSecretKey)
-		return jwtSecret, nil
-	})
+	claims := &userServices.Claims{}
+	tokenJwt, err := jwt.ParseWithClaims(tokenString, claims, jwk.Keyfunc, jwt.WithValidMethods(jwk.ValidMethods()))
 
 	if err != nil {
-		fmt.Println("❌ [ERROR] JWT parse gagal:", err)
+		logger.FromContext(c.Request.Context()).WithError(err).Debug("failed to parse bearer token")
+		metrics.JWTFailures.WithLabelValues(jwtFailureReason(err)).Inc()
 		return errConstant.ErrUnauthorized
 	}
 	if !tokenJwt.Valid {
-		fmt.Println("❌ [ERROR] JWT tidak valid")
+		metrics.JWTFailures.WithLabelValues(metrics.JWTInvalid).Inc()
 		return errConstant.ErrUnauthorized
 	}
+	if claims.User == nil || claims.ID == "" || claims.IssuedAt == nil {
+		metrics.JWTFailures.WithLabelValues(metrics.JWTInvalid).Inc()
+		return errConstant.ErrInvalidToken
+	}
+
+	revoked, err := service.GetRevocation().IsRevoked(c.Request.Context(), claims.ID, claims.User.UUID, claims.IssuedAt.Time)
+	if err != nil {
+		metrics.JWTFailures.WithLabelValues(metrics.JWTError).Inc()
+		return errConstant.ErrInternalServerError
+	}
+	if revoked {
+		metrics.JWTFailures.WithLabelValues(metrics.JWTRevoked).Inc()
+		return errConstant.ErrTokenRevoked
+	}
+
+	active, err := service.GetRevocation().IsUserActive(c.Request.Context(), claims.User.UUID)
+	if err != nil {
+		metrics.JWTFailures.WithLabelValues(metrics.JWTError).Inc()
+		return errConstant.ErrInternalServerError
+	}
+	if !active {
+		metrics.JWTFailures.WithLabelValues(metrics.JWTInactive).Inc()
+		return errConstant.ErrUserInactive
+	}
 
-	fmt.Println("✅ [INFO] JWT valid")
-	userLogin := c.Request.WithContext(context.WithValue(c.Request.Context(), constants.UserLogin, claims.User))
-	c.Request = userLogin
+	ctx := context.WithValue(c.Request.Context(), constants.UserLogin, claims.User)
+	ctx = context.WithValue(ctx, constants.Claims, claims)
+	ctx = logger.WithFields(ctx, logrus.Fields{"user_uuid": claims.User.UUID.String()})
+	c.Request = c.Request.WithContext(ctx)
 	c.Set(constants.Token, token)
 	return nil
 }
 
-func Authenticate() gin.HandlerFunc {
+func jwtFailureReason(err error) string {
+	switch {
+	case errors.Is(err, jwt.ErrTokenMalformed):
+		return metrics.JWTMalformed
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return metrics.JWTExpired
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid):
+		return metrics.JWTInvalidSignature
+	default:
+		return metrics.JWTInvalid
+	}
+}
+
+func apiKeyFailureReason(err error) string {
+	switch {
+	case errors.Is(err, errConstant.ErrInvalidAPIKey):
+		return metrics.APIKeyInvalid
+	case errors.Is(err, errConstant.ErrAPIKeyExpired):
+		return metrics.APIKeyExpired
+	case errors.Is(err, errConstant.ErrAPIKeyReplayed):
+		return metrics.APIKeyReplayed
+	case errors.Is(err, errConstant.ErrForbidden):
+		return metrics.APIKeyForbidden
+	case errors.Is(err, errConstant.ErrRequestTooLarge):
+		return metrics.APIKeyTooLarge
+	default:
+		return metrics.APIKeyError
+	}
+}
+
+func mustChangePassword(c *gin.Context) bool {
+	claims, ok := c.Request.Context().Value(constants.Claims).(*userServices.Claims)
+	return ok && claims.User != nil && claims.User.MustChangePassword
+}
+
+// authenticateUser validates the bearer token and writes the error response
+// when it is missing or invalid.
+func authenticateUser(c *gin.Context, service services.IServiceRegistry, allowPendingPasswordChange bool) bool {
+	token := c.GetHeader("Authorization")
+	if token == "" {
+		metrics.JWTFailures.WithLabelValues(metrics.JWTMissing).Inc()
+		responseUnauthorized(c, errConstant.ErrUnauthorized.Error())
+		return false
+	}
+
+	err := validateBearerToken(c, token, service)
+	if err != nil {
+		logger.FromContext(c.Request.Context()).WithError(err).Info("bearer token rejected")
+		responseUnauthorized(c, err.Error())
+		return false
+	}
+
+	if !allowPendingPasswordChange && mustChangePassword(c) {
+		c.JSON(http.StatusForbidden, response.Response{
+			Status:    constants.Error,
+			Message:   errConstant.ErrPasswordChangeRequired.Error(),
+			RequestID: requestid.FromContext(c.Request.Context()),
+		})
+		c.Abort()
+		return false
+	}
+	return true
+}
+
+// authenticateService validates the API key headers and writes the error
+// response when they are missing or invalid.
+func authenticateService(c *gin.Context, service services.IServiceRegistry) bool {
+	err := validateAPIKey(c, service)
+	if err != nil {
+		metrics.APIKeyFailures.WithLabelValues(apiKeyFailureReason(err)).Inc()
+		logger.FromContext(c.Request.Context()).
+			WithError(err).
+			WithField("service", c.GetHeader(constants.XserviceName)).
+			Info("api key rejected")
+		if errors.Is(err, errConstant.ErrForbidden) {
+			responseForbidden(c)
+			return false
+		}
+		if errors.Is(err, errConstant.ErrRequestTooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, response.Response{
+				Status:    constants.Error,
+				Message:   err.Error(),
+				RequestID: requestid.FromContext(c.Request.Context()),
+			})
+			c.Abort()
+			return false
+		}
+		responseUnauthorized(c, err.Error())
+		return false
+	}
+	return true
+}
+
+// Authenticate requires both a valid bearer token and API key, for requests a
+// service makes on behalf of a user. Users who still have to change their
+// password are rejected until they do.
+func Authenticate(service services.IServiceRegistry) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		fmt.Println("[DEBUG] Authenticate Middleware Kepanggil")
-		var err error
-		token := c.GetHeader("Authorization")
-		if token == "" {
-			fmt.Println("❌ [ERROR] Authorization header tidak ditemukan")
-			fmt.Println("Headers:", c.Request.Header)
-			responseUnauthorized(c, errConstant.ErrUnauthorized.Error())
-			return
+		if authenticateUser(c, service, false) && authenticateService(c, service) {
+			c.Next()
 		}
+	}
+}
 
-		err = validateBearerToken(c, token)
-		if err != nil {
-			fmt.Println("❌ [ERROR] Validasi Bearer token gagal:", err)
-			responseUnauthorized(c, err.Error())
-			return
+// AuthenticateAllowPasswordChange is Authenticate for the few routes a user
+// with a pending forced password change may still call.
+func AuthenticateAllowPasswordChange(service services.IServiceRegistry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if authenticateUser(c, service, true) && authenticateService(c, service) {
+			c.Next()
 		}
+	}
+}
 
-		err = validateAPIKey(c)
-		if err != nil {
-			fmt.Println("❌ [ERROR] Validasi API Key gagal:", err)
-			responseUnauthorized(c, err.Error())
-			return
+// AuthenticateService requires only a valid API key, for internal routes a
+// service calls without a user. The caller is available as a
+// *dto.ServicePrincipal under constants.ServiceLogin.
+func AuthenticateService(service services.IServiceRegistry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if authenticateService(c, service) {
+			c.Next()
 		}
-		fmt.Println("✅ [INFO] Bearer token valid")
-		fmt.Println("✅ [INFO] API Key valid")
-		c.Next()
 	}
 }
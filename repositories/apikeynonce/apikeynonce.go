@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"user-service/domain/models"
+
+	errWrap "user-service/common/error"
+	errConstant "user-service/constants/error"
+
+	"gorm.io/gorm"
+)
+
+type APIKeyNonceRepository struct {
+	db *gorm.DB
+}
+
+type IAPIKeyNonceRepository interface {
+	Use(context.Context, string, string, time.Time) (bool, error)
+	DeleteExpired(context.Context, time.Time) (int64, error)
+}
+
+func NewAPIKeyNonceRepository(db *gorm.DB) IAPIKeyNonceRepository {
+	return &APIKeyNonceRepository{db: db}
+}
+
+// Use stores the nonce until expiresAt and reports whether it was unused. The
+// unique index makes concurrent uses on different replicas race on one row,
+// and a nonce whose previous use has expired may be taken again.
+func (r *APIKeyNonceRepository) Use(ctx context.Context, serviceName, nonce string, expiresAt time.Time) (bool, error) {
+	now := time.Now()
+	result := r.db.WithContext(ctx).Exec(`
+		INSERT INTO api_key_nonces (service_name, nonce, expires_at, created_at)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (service_name, nonce) DO UPDATE
+		SET expires_at = EXCLUDED.expires_at, created_at = EXCLUDED.created_at
+		WHERE api_key_nonces.expires_at <= ?`,
+		serviceName, nonce, expiresAt, now, now,
+	)
+	if result.Error != nil {
+		return false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *APIKeyNonceRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("expires_at <= ?", before).
+		Delete(&models.APIKeyNonce{})
+	if result.Error != nil {
+		return 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"context"
+	"user-service/domain/models"
+
+	errWrap "user-service/common/error"
+	errConstant "user-service/constants/error"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+type IAuditRepository interface {
+	Create(context.Context, *models.AuditLog) error
+	FindByUserUUID(context.Context, uuid.UUID) ([]models.AuditLog, error)
+}
+
+func NewAuditRepository(db *gorm.DB) IAuditRepository {
+	return &AuditRepository{db: db}
+}
+
+func (r *AuditRepository) Create(ctx context.Context, log *models.AuditLog) error {
+	err := r.db.WithContext(ctx).Create(log).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
+
+// FindByUserUUID returns every entry where the user is the actor or the
+// target, oldest first.
+func (r *AuditRepository) FindByUserUUID(ctx context.Context, userUUID uuid.UUID) ([]models.AuditLog, error) {
+	var logs []models.AuditLog
+	err := r.db.WithContext(ctx).
+		Where("actor_uuid = ? OR target_uuid = ?", userUUID, userUUID).
+		Order("created_at ASC, id ASC").
+		Find(&logs).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return logs, nil
+}
@@ -0,0 +1,87 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+	"user-service/domain/models"
+
+	errWrap "user-service/common/error"
+	errConstant "user-service/constants/error"
+
+	"gorm.io/gorm"
+)
+
+type LoginAttemptRepository struct {
+	db *gorm.DB
+}
+
+type ILoginAttemptRepository interface {
+	FindByKeys(context.Context, []string) ([]models.LoginAttempt, error)
+	RecordFailure(context.Context, string, time.Time) (*models.LoginAttempt, error)
+	Lock(context.Context, string, time.Time) error
+	Reset(context.Context, []string) error
+}
+
+func NewLoginAttemptRepository(db *gorm.DB) ILoginAttemptRepository {
+	return &LoginAttemptRepository{db: db}
+}
+
+func (r *LoginAttemptRepository) FindByKeys(ctx context.Context, keys []string) ([]models.LoginAttempt, error) {
+	var attempts []models.LoginAttempt
+	err := r.db.WithContext(ctx).
+		Where("key IN ?", keys).
+		Find(&attempts).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return attempts, nil
+}
+
+// RecordFailure atomically increments the failure counter of key, starting
+// over when the previous failure happened before windowStart.
+func (r *LoginAttemptRepository) RecordFailure(ctx context.Context, key string, windowStart time.Time) (*models.LoginAttempt, error) {
+	var attempt models.LoginAttempt
+	now := time.Now()
+	err := r.db.WithContext(ctx).Raw(`
+		INSERT INTO login_attempts (key, failed_count, last_failed_at, created_at, updated_at)
+		VALUES (@key, 1, @now, @now, @now)
+		ON CONFLICT (key) DO UPDATE SET
+			failed_count = CASE
+				WHEN login_attempts.last_failed_at IS NULL OR login_attempts.last_failed_at < @window_start THEN 1
+				ELSE login_attempts.failed_count + 1
+			END,
+			last_failed_at = @now,
+			updated_at = @now
+		RETURNING *`,
+		map[string]any{"key": key, "now": now, "window_start": windowStart},
+	).Scan(&attempt).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	if attempt.ID == 0 {
+		return nil, errWrap.WrapError(errors.New("login attempt was not recorded"))
+	}
+	return &attempt, nil
+}
+
+func (r *LoginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.LoginAttempt{}).
+		Where("key = ?", key).
+		Update("locked_until", until).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
+
+func (r *LoginAttemptRepository) Reset(ctx context.Context, keys []string) error {
+	err := r.db.WithContext(ctx).
+		Where("key IN ?", keys).
+		Delete(&models.LoginAttempt{}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
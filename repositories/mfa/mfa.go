@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+	"user-service/domain/models"
+
+	errWrap "user-service/common/error"
+	errConstant "user-service/constants/error"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MFARepository struct {
+	db *gorm.DB
+}
+
+type IMFARepository interface {
+	FindByUserID(context.Context, uint) (*models.UserMFA, error)
+	Upsert(context.Context, *models.UserMFA) error
+	Enable(context.Context, uint, int64, []string) error
+	UseStep(context.Context, uint, int64) (bool, error)
+	UseRecoveryCode(context.Context, uint, string) (bool, error)
+	Delete(context.Context, uint) error
+}
+
+func NewMFARepository(db *gorm.DB) IMFARepository {
+	return &MFARepository{db: db}
+}
+
+// FindByUserID returns the user's MFA enrollment, or nil when there is none.
+func (r *MFARepository) FindByUserID(ctx context.Context, userID uint) (*models.UserMFA, error) {
+	var mfa models.UserMFA
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		First(&mfa).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return &mfa, nil
+}
+
+func (r *MFARepository) Upsert(ctx context.Context, mfa *models.UserMFA) error {
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"secret", "enabled_at", "last_used_step", "updated_at"}),
+		}).
+		Create(mfa).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
+
+// Enable activates the enrollment and replaces the user's recovery codes.
+func (r *MFARepository) Enable(ctx context.Context, userID uint, step int64, codeHashes []string) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.UserMFA{}).
+			Where("user_id = ?", userID).
+			Updates(map[string]any{"enabled_at": time.Now(), "last_used_step": step}).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error
+		if err != nil {
+			return err
+		}
+
+		codes := make([]models.RecoveryCode, 0, len(codeHashes))
+		for _, hash := range codeHashes {
+			codes = append(codes, models.RecoveryCode{UserID: userID, CodeHash: hash})
+		}
+		return tx.Create(&codes).Error
+	})
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
+
+// UseStep records step as the last accepted TOTP step and reports false when
+// that step, or a later one, was already used.
+func (r *MFARepository) UseStep(ctx context.Context, userID uint, step int64) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.UserMFA{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		return false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *MFARepository) UseRecoveryCode(ctx context.Context, userID uint, codeHash string) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *MFARepository) Delete(ctx context.Context, userID uint) error {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&models.UserMFA{}).Error
+	})
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"context"
+	"user-service/domain/models"
+
+	errWrap "user-service/common/error"
+	errConstant "user-service/constants/error"
+
+	"gorm.io/gorm"
+)
+
+type PermissionRepository struct {
+	db *gorm.DB
+}
+
+type IPermissionRepository interface {
+	FindCodesByRoleCode(context.Context, string) ([]string, error)
+}
+
+func NewPermissionRepository(db *gorm.DB) IPermissionRepository {
+	return &PermissionRepository{db: db}
+}
+
+func (r *PermissionRepository) FindCodesByRoleCode(ctx context.Context, roleCode string) ([]string, error) {
+	var codes []string
+	err := r.db.WithContext(ctx).
+		Model(&models.Permission{}).
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Joins("JOIN roles ON roles.id = role_permissions.role_id").
+		Where("LOWER(roles.code) = LOWER(?)", roleCode).
+		Pluck("permissions.code", &codes).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return codes, nil
+}
@@ -1,7 +1,17 @@
 package repositories
 
 import (
+	apiKeyNonceRepositories "user-service/repositories/apikeynonce"
+	auditRepositories "user-service/repositories/audit"
+	loginAttemptRepositories "user-service/repositories/loginattempt"
+	mfaRepositories "user-service/repositories/mfa"
+	permissionRepositories "user-service/repositories/permission"
+	revocationRepositories "user-service/repositories/revocation"
+	roleRepositories "user-service/repositories/role"
+	serviceClientRepositories "user-service/repositories/serviceclient"
+	tokenRepositories "user-service/repositories/token"
 	repositories "user-service/repositories/user"
+	userTokenRepositories "user-service/repositories/usertoken"
 
 	"gorm.io/gorm"
 )
@@ -12,6 +22,16 @@ type Registry struct {
 
 type IRepositoryRegistry interface {
 	GetUser() repositories.IUserRepository
+	GetToken() tokenRepositories.ITokenRepository
+	GetRevocation() revocationRepositories.IRevocationRepository
+	GetPermission() permissionRepositories.IPermissionRepository
+	GetAudit() auditRepositories.IAuditRepository
+	GetUserToken() userTokenRepositories.IUserTokenRepository
+	GetMFA() mfaRepositories.IMFARepository
+	GetLoginAttempt() loginAttemptRepositories.ILoginAttemptRepository
+	GetRole() roleRepositories.IRoleRepository
+	GetServiceClient() serviceClientRepositories.IServiceClientRepository
+	GetAPIKeyNonce() apiKeyNonceRepositories.IAPIKeyNonceRepository
 }
 
 func NewRepositoryRegistry(db *gorm.DB) IRepositoryRegistry {
@@ -19,5 +39,45 @@ func NewRepositoryRegistry(db *gorm.DB) IRepositoryRegistry {
 }
 
 func (r *Registry) GetUser() repositories.IUserRepository {
-	return repositories.NewUserRepository(r.db)
+	return repositories.NewTracedUserRepository(repositories.NewUserRepository(r.db))
+}
+
+func (r *Registry) GetToken() tokenRepositories.ITokenRepository {
+	return tokenRepositories.NewTokenRepository(r.db)
+}
+
+func (r *Registry) GetRevocation() revocationRepositories.IRevocationRepository {
+	return revocationRepositories.NewRevocationRepository(r.db)
+}
+
+func (r *Registry) GetPermission() permissionRepositories.IPermissionRepository {
+	return permissionRepositories.NewPermissionRepository(r.db)
+}
+
+func (r *Registry) GetAudit() auditRepositories.IAuditRepository {
+	return auditRepositories.NewAuditRepository(r.db)
+}
+
+func (r *Registry) GetUserToken() userTokenRepositories.IUserTokenRepository {
+	return userTokenRepositories.NewUserTokenRepository(r.db)
+}
+
+func (r *Registry) GetMFA() mfaRepositories.IMFARepository {
+	return mfaRepositories.NewMFARepository(r.db)
+}
+
+func (r *Registry) GetLoginAttempt() loginAttemptRepositories.ILoginAttemptRepository {
+	return loginAttemptRepositories.NewLoginAttemptRepository(r.db)
+}
+
+func (r *Registry) GetRole() roleRepositories.IRoleRepository {
+	return roleRepositories.NewRoleRepository(r.db)
+}
+
+func (r *Registry) GetServiceClient() serviceClientRepositories.IServiceClientRepository {
+	return serviceClientRepositories.NewServiceClientRepository(r.db)
+}
+
+func (r *Registry) GetAPIKeyNonce() apiKeyNonceRepositories.IAPIKeyNonceRepository {
+	return apiKeyNonceRepositories.NewAPIKeyNonceRepository(r.db)
 }
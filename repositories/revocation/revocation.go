@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+	"user-service/domain/models"
+
+	errWrap "user-service/common/error"
+	errConstant "user-service/constants/error"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RevocationRepository struct {
+	db *gorm.DB
+}
+
+type IRevocationRepository interface {
+	RevokeToken(context.Context, *models.RevokedToken) error
+	IsTokenRevoked(context.Context, string) (bool, error)
+	RevokeAllForUser(context.Context, uuid.UUID, time.Time) error
+	FindUserRevocation(context.Context, uuid.UUID) (*models.UserTokenRevocation, error)
+	DeleteExpired(context.Context, time.Time) (int64, error)
+}
+
+func NewRevocationRepository(db *gorm.DB) IRevocationRepository {
+	return &RevocationRepository{db: db}
+}
+
+func (r *RevocationRepository) RevokeToken(ctx context.Context, token *models.RevokedToken) error {
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "jti"}}, DoNothing: true}).
+		Create(token).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
+
+func (r *RevocationRepository) IsTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.RevokedToken{}).
+		Where("jti = ?", jti).
+		Count(&count).Error
+	if err != nil {
+		return false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return count > 0, nil
+}
+
+func (r *RevocationRepository) RevokeAllForUser(ctx context.Context, userUUID uuid.UUID, revokedAt time.Time) error {
+	err := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_uuid"}},
+			DoUpdates: clause.AssignmentColumns([]string{"revoked_at", "updated_at"}),
+		}).
+		Create(&models.UserTokenRevocation{UserUUID: userUUID, RevokedAt: revokedAt}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
+
+func (r *RevocationRepository) FindUserRevocation(ctx context.Context, userUUID uuid.UUID) (*models.UserTokenRevocation, error) {
+	var revocation models.UserTokenRevocation
+	err := r.db.WithContext(ctx).
+		Where("user_uuid = ?", userUUID).
+		First(&revocation).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return &revocation, nil
+}
+
+// DeleteExpired removes revoked tokens that expired before the given time,
+// since an expired token is rejected without looking it up.
+func (r *RevocationRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("expires_at <= ?", before).
+		Delete(&models.RevokedToken{})
+	if result.Error != nil {
+		return 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return result.RowsAffected, nil
+}
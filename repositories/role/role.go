@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"user-service/domain/models"
+
+	errWrap "user-service/common/error"
+	errConstant "user-service/constants/error"
+
+	"gorm.io/gorm"
+)
+
+type RoleRepository struct {
+	db *gorm.DB
+}
+
+type IRoleRepository interface {
+	FindByCode(context.Context, string) (*models.Role, error)
+}
+
+func NewRoleRepository(db *gorm.DB) IRoleRepository {
+	return &RoleRepository{db: db}
+}
+
+func (r *RoleRepository) FindByCode(ctx context.Context, code string) (*models.Role, error) {
+	var role models.Role
+	err := r.db.WithContext(ctx).
+		Where("LOWER(code) = LOWER(?)", code).
+		First(&role).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errConstant.ErrRoleNotFound
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return &role, nil
+}
@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+	"user-service/domain/models"
+
+	errWrap "user-service/common/error"
+	errConstant "user-service/constants/error"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+const pgUniqueViolation = "23505"
+
+type ServiceClientRepository struct {
+	db *gorm.DB
+}
+
+type IServiceClientRepository interface {
+	Create(context.Context, *models.ServiceClient) error
+	FindByName(context.Context, string) (*models.ServiceClient, error)
+	FindAll(context.Context) ([]models.ServiceClient, error)
+	UpdateCredentials(context.Context, *models.ServiceClient) error
+	UpdateLastUsedAt(context.Context, uint, time.Time) error
+}
+
+func NewServiceClientRepository(db *gorm.DB) IServiceClientRepository {
+	return &ServiceClientRepository{db: db}
+}
+
+func (r *ServiceClientRepository) Create(ctx context.Context, client *models.ServiceClient) error {
+	err := r.db.WithContext(ctx).Create(client).Error
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation && pgErr.ConstraintName == models.ServiceClientNameIndex {
+			return errConstant.ErrServiceClientExist
+		}
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
+
+func (r *ServiceClientRepository) FindByName(ctx context.Context, name string) (*models.ServiceClient, error) {
+	var client models.ServiceClient
+	err := r.db.WithContext(ctx).
+		Where("name = ?", name).
+		First(&client).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errConstant.ErrServiceClientNotFound
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return &client, nil
+}
+
+func (r *ServiceClientRepository) FindAll(ctx context.Context) ([]models.ServiceClient, error) {
+	var clients []models.ServiceClient
+	err := r.db.WithContext(ctx).
+		Order("name ASC").
+		Find(&clients).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return clients, nil
+}
+
+// UpdateCredentials saves the secrets, scopes and state of the client,
+// including fields set back to their zero value.
+func (r *ServiceClientRepository) UpdateCredentials(ctx context.Context, client *models.ServiceClient) error {
+	err := r.db.WithContext(ctx).
+		Model(client).
+		Select("secret", "previous_secret", "previous_secret_expires_at", "scopes", "enabled", "revoked_at", "updated_at").
+		Updates(client).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
+
+func (r *ServiceClientRepository) UpdateLastUsedAt(ctx context.Context, id uint, usedAt time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.ServiceClient{}).
+		Where("id = ?", id).
+		UpdateColumn("last_used_at", usedAt).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+	"user-service/domain/models"
+
+	errWrap "user-service/common/error"
+	errConstant "user-service/constants/error"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TokenRepository struct {
+	db *gorm.DB
+}
+
+type ITokenRepository interface {
+	Create(context.Context, *models.RefreshToken) (*models.RefreshToken, error)
+	FindByHash(context.Context, string) (*models.RefreshToken, error)
+	Revoke(context.Context, uint, *uint) (bool, error)
+	RevokeFamily(context.Context, uuid.UUID) error
+	RevokeAllByUserID(context.Context, uint) error
+	FindByUserID(context.Context, uint) ([]models.RefreshToken, error)
+}
+
+func NewTokenRepository(db *gorm.DB) ITokenRepository {
+	return &TokenRepository{db: db}
+}
+
+func (r *TokenRepository) Create(ctx context.Context, token *models.RefreshToken) (*models.RefreshToken, error) {
+	err := r.db.WithContext(ctx).Create(token).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return token, nil
+}
+
+func (r *TokenRepository) FindByHash(ctx context.Context, hash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := r.db.WithContext(ctx).
+		Where("token_hash = ?", hash).
+		First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errConstant.ErrInvalidRefreshToken
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return &token, nil
+}
+
+// Revoke marks a still-active token as revoked and reports whether this call
+// was the one that revoked it, so concurrent rotations of the same token can
+// be told apart from the first legitimate use.
+func (r *TokenRepository) Revoke(ctx context.Context, id uint, replacedByID *uint) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]any{
+			"revoked_at":     time.Now(),
+			"replaced_by_id": replacedByID,
+		})
+	if result.Error != nil {
+		return false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *TokenRepository) RevokeFamily(ctx context.Context, familyID uuid.UUID) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
+
+func (r *TokenRepository) RevokeAllByUserID(ctx context.Context, userID uint) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
+
+func (r *TokenRepository) FindByUserID(ctx context.Context, userID uint) ([]models.RefreshToken, error) {
+	var tokens []models.RefreshToken
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at ASC, id ASC").
+		Find(&tokens).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return tokens, nil
+}
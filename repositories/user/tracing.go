@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"context"
+	"time"
+	"user-service/common/tracing"
+	"user-service/domain/dto"
+	"user-service/domain/models"
+)
+
+// TracedUserRepository opens a span around every IUserRepository call.
+type TracedUserRepository struct {
+	next IUserRepository
+}
+
+func NewTracedUserRepository(next IUserRepository) IUserRepository {
+	return &TracedUserRepository{next: next}
+}
+
+func (r *TracedUserRepository) Register(ctx context.Context, req *dto.RegisterRequest) (user *models.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.Register")
+	defer func() { tracing.End(span, err) }()
+	return r.next.Register(ctx, req)
+}
+
+func (r *TracedUserRepository) Update(ctx context.Context, req *dto.UpdateRequest, uuid string) (user *models.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.Update")
+	defer func() { tracing.End(span, err) }()
+	return r.next.Update(ctx, req, uuid)
+}
+
+func (r *TracedUserRepository) FindByUsername(ctx context.Context, username string) (user *models.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.FindByUsername")
+	defer func() { tracing.End(span, err) }()
+	return r.next.FindByUsername(ctx, username)
+}
+
+func (r *TracedUserRepository) FindByEmail(ctx context.Context, email string) (user *models.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.FindByEmail")
+	defer func() { tracing.End(span, err) }()
+	return r.next.FindByEmail(ctx, email)
+}
+
+func (r *TracedUserRepository) FindByPhoneNumber(ctx context.Context, phoneNumber string) (user *models.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.FindByPhoneNumber")
+	defer func() { tracing.End(span, err) }()
+	return r.next.FindByPhoneNumber(ctx, phoneNumber)
+}
+
+func (r *TracedUserRepository) FindByUUID(ctx context.Context, uuid string) (user *models.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.FindByUUID")
+	defer func() { tracing.End(span, err) }()
+	return r.next.FindByUUID(ctx, uuid)
+}
+
+func (r *TracedUserRepository) FindByUUIDWithDeleted(ctx context.Context, uuid string) (user *models.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.FindByUUIDWithDeleted")
+	defer func() { tracing.End(span, err) }()
+	return r.next.FindByUUIDWithDeleted(ctx, uuid)
+}
+
+func (r *TracedUserRepository) FindByIDWithRole(ctx context.Context, id uint) (user *models.User, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.FindByIDWithRole")
+	defer func() { tracing.End(span, err) }()
+	return r.next.FindByIDWithRole(ctx, id)
+}
+
+func (r *TracedUserRepository) FindAll(ctx context.Context, req *dto.UserListRequest, cursor *dto.UserCursor) (users []models.User, total int64, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.FindAll")
+	defer func() { tracing.End(span, err) }()
+	return r.next.FindAll(ctx, req, cursor)
+}
+
+func (r *TracedUserRepository) UpdatePassword(ctx context.Context, id uint, password string, mustChange bool) (err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.UpdatePassword")
+	defer func() { tracing.End(span, err) }()
+	return r.next.UpdatePassword(ctx, id, password, mustChange)
+}
+
+func (r *TracedUserRepository) UpdateEmailVerifiedAt(ctx context.Context, id uint, verifiedAt *time.Time) (err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.UpdateEmailVerifiedAt")
+	defer func() { tracing.End(span, err) }()
+	return r.next.UpdateEmailVerifiedAt(ctx, id, verifiedAt)
+}
+
+func (r *TracedUserRepository) UpdateStatus(ctx context.Context, id uint, status string) (err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.UpdateStatus")
+	defer func() { tracing.End(span, err) }()
+	return r.next.UpdateStatus(ctx, id, status)
+}
+
+func (r *TracedUserRepository) UpdateRole(ctx context.Context, id uint, roleID uint) (err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.UpdateRole")
+	defer func() { tracing.End(span, err) }()
+	return r.next.UpdateRole(ctx, id, roleID)
+}
+
+func (r *TracedUserRepository) Delete(ctx context.Context, id uint) (err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.Delete")
+	defer func() { tracing.End(span, err) }()
+	return r.next.Delete(ctx, id)
+}
+
+func (r *TracedUserRepository) Erase(
+	ctx context.Context,
+	user *models.User,
+	proof func(map[string]int64) *models.AuditLog,
+) (erased map[string]int64, err error) {
+	ctx, span := tracing.Start(ctx, "UserRepository.Erase")
+	defer func() { tracing.End(span, err) }()
+	return r.next.Erase(ctx, user, proof)
+}
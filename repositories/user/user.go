@@ -2,7 +2,13 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"user-service/common/util"
+	"user-service/constants"
 	"user-service/domain/dto"
 	"user-service/domain/models"
 
@@ -10,9 +16,12 @@ import (
 	errConstant "user-service/constants/error"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/gorm"
 )
 
+const pgUniqueViolation = "23505"
+
 type UserRepository struct {
 	db *gorm.DB
 }
@@ -22,8 +31,17 @@ type IUserRepository interface {
 	Update(context.Context, *dto.UpdateRequest, string) (*models.User, error)
 	FindByUsername(context.Context, string) (*models.User, error)
 	FindByEmail(context.Context, string) (*models.User, error)
+	FindByPhoneNumber(context.Context, string) (*models.User, error)
 	FindByUUID(context.Context, string) (*models.User, error)
+	FindByUUIDWithDeleted(context.Context, string) (*models.User, error)
 	FindByIDWithRole(ctx context.Context, id uint) (*models.User, error)
+	FindAll(context.Context, *dto.UserListRequest, *dto.UserCursor) ([]models.User, int64, error)
+	UpdatePassword(context.Context, uint, string, bool) error
+	UpdateEmailVerifiedAt(context.Context, uint, *time.Time) error
+	UpdateStatus(context.Context, uint, string) error
+	UpdateRole(context.Context, uint, uint) error
+	Delete(context.Context, uint) error
+	Erase(context.Context, *models.User, func(map[string]int64) *models.AuditLog) (map[string]int64, error)
 	// Preload(column string) *gorm.DB
 }
 
@@ -35,15 +53,18 @@ func (r *UserRepository) Register(ctx context.Context, req *dto.RegisterRequest)
 	user := models.User{
 		UUID:        uuid.New(),
 		Name:        req.Name,
-		UserName:    req.UserName,
+		UserName:    util.NormalizeIdentifier(req.UserName),
 		Password:    req.Password,
-		PhoneNumber: req.PhoneNumber,
-		Email:       req.Email,
+		PhoneNumber: strings.TrimSpace(req.PhoneNumber),
+		Email:       util.NormalizeIdentifier(req.Email),
 		RoleID:      req.RoleID,
 	}
 
 	err := r.db.WithContext(ctx).Create(&user).Error
 	if err != nil {
+		if existErr := uniqueViolation(err); existErr != nil {
+			return nil, existErr
+		}
 		return nil, errWrap.WrapError(errConstant.ErrSQLError)
 	}
 	return &user, nil
@@ -52,16 +73,19 @@ func (r *UserRepository) Register(ctx context.Context, req *dto.RegisterRequest)
 func (r *UserRepository) Update(ctx context.Context, req *dto.UpdateRequest, uuid string) (*models.User, error) {
 	user := models.User{
 		Name:        req.Name,
-		UserName:    req.Username,
+		UserName:    util.NormalizeIdentifier(req.Username),
 		Password:    *req.Password,
-		PhoneNumber: req.PhoneNumber,
-		Email:       req.Email,
+		PhoneNumber: strings.TrimSpace(req.PhoneNumber),
+		Email:       util.NormalizeIdentifier(req.Email),
 	}
 
 	err := r.db.WithContext(ctx).Model(&user).
 		Where("uuid = ?", uuid).
 		Updates(user).Error
 	if err != nil {
+		if existErr := uniqueViolation(err); existErr != nil {
+			return nil, existErr
+		}
 		return nil, errWrap.WrapError(errConstant.ErrSQLError)
 	}
 	return &user, nil
@@ -71,7 +95,7 @@ func (r *UserRepository) FindByUsername(ctx context.Context, username string) (*
 	var user models.User
 	err := r.db.WithContext(ctx).
 		Preload("Role").
-		Where("user_name = ?", username).
+		Where("LOWER(user_name) = ?", util.NormalizeIdentifier(username)).
 		First(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -86,7 +110,7 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*models
 	var user models.User
 	err := r.db.WithContext(ctx).
 		Preload("Role").
-		Where("email = ?", email).
+		Where("LOWER(email) = ?", util.NormalizeIdentifier(email)).
 		First(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -97,6 +121,24 @@ func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*models
 	return &user, nil
 }
 
+// FindByPhoneNumber only returns a user when the phone number is not shared
+// with another account, since phone numbers are not unique.
+func (r *UserRepository) FindByPhoneNumber(ctx context.Context, phoneNumber string) (*models.User, error) {
+	var users []models.User
+	err := r.db.WithContext(ctx).
+		Preload("Role").
+		Where("phone_number = ?", strings.TrimSpace(phoneNumber)).
+		Limit(2).
+		Find(&users).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	if len(users) != 1 {
+		return nil, errConstant.ErrUserNotFound
+	}
+	return &users[0], nil
+}
+
 func (r *UserRepository) FindByUUID(ctx context.Context, uuid string) (*models.User, error) {
 	var user models.User
 	err := r.db.WithContext(ctx).
@@ -112,6 +154,23 @@ func (r *UserRepository) FindByUUID(ctx context.Context, uuid string) (*models.U
 	return &user, nil
 }
 
+// FindByUUIDWithDeleted is FindByUUID including soft deleted users.
+func (r *UserRepository) FindByUUIDWithDeleted(ctx context.Context, uuid string) (*models.User, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).
+		Unscoped().
+		Preload("Role").
+		Where("uuid = ?", uuid).
+		First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errConstant.ErrUserNotFound
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return &user, nil
+}
+
 func (r *UserRepository) FindByIDWithRole(ctx context.Context, id uint) (*models.User, error) {
 	var user models.User
 	if err := r.db.WithContext(ctx).Preload("Role").First(&user, id).Error; err != nil {
@@ -120,6 +179,228 @@ func (r *UserRepository) FindByIDWithRole(ctx context.Context, id uint) (*models
 	return &user, nil
 }
 
+// UpdatePassword sets the password hash and whether the user has to change it
+// on their next login.
+func (r *UserRepository) UpdatePassword(ctx context.Context, id uint, password string, mustChange bool) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"password":             password,
+			"must_change_password": mustChange,
+		}).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
+
+func (r *UserRepository) UpdateEmailVerifiedAt(ctx context.Context, id uint, verifiedAt *time.Time) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ?", id).
+		Update("email_verified_at", verifiedAt).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
+
+func (r *UserRepository) UpdateStatus(ctx context.Context, id uint, status string) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ?", id).
+		Update("status", status).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
+
+func (r *UserRepository) UpdateRole(ctx context.Context, id uint, roleID uint) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ?", id).
+		Update("role_id", roleID).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
+
+// Delete soft deletes the user; the row is kept but hidden from every query.
+func (r *UserRepository) Delete(ctx context.Context, id uint) error {
+	err := r.db.WithContext(ctx).Delete(&models.User{}, id).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
+
+// Erase anonymizes the user and deletes the rows they own in one transaction.
+// The audit entry built by proof from the affected row counts is written in
+// the same transaction, so nothing is erased unless the proof is stored.
+func (r *UserRepository) Erase(
+	ctx context.Context,
+	user *models.User,
+	proof func(affected map[string]int64) *models.AuditLog,
+) (map[string]int64, error) {
+	affected := map[string]int64{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		owned := map[string]any{
+			"refresh_tokens": &models.RefreshToken{},
+			"user_tokens":    &models.UserToken{},
+			"recovery_codes": &models.RecoveryCode{},
+			"user_mfas":      &models.UserMFA{},
+		}
+		for table, model := range owned {
+			result := tx.Where("user_id = ?", user.ID).Delete(model)
+			if result.Error != nil {
+				return result.Error
+			}
+			affected[table] = result.RowsAffected
+		}
+
+		// Audit entries stay for accountability, minus the client IP and
+		// the reasons that quote old usernames or emails.
+		result := tx.Model(&models.AuditLog{}).
+			Where("actor_uuid = ? OR target_uuid = ?", user.UUID, user.UUID).
+			Update("ip_address", "")
+		if result.Error != nil {
+			return result.Error
+		}
+		affected["audit_logs"] = result.RowsAffected
+		result = tx.Model(&models.AuditLog{}).
+			Where("target_uuid = ? AND action = ?", user.UUID, constants.AuditActionDeduplicate).
+			Update("reason", "")
+		if result.Error != nil {
+			return result.Error
+		}
+
+		now := time.Now()
+		result = tx.Unscoped().
+			Model(&models.User{}).
+			Where("id = ?", user.ID).
+			Updates(map[string]any{
+				"name":              "Erased User",
+				"user_name":         fmt.Sprintf("erased_%d", user.ID),
+				"email":             fmt.Sprintf("erased-%d@users.invalid", user.ID),
+				"phone_number":      "",
+				"password":          "",
+				"status":            constants.UserStatusDeactivated,
+				"email_verified_at": nil,
+				"erased_at":         now,
+				"deleted_at":        now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		affected["users"] = result.RowsAffected
+
+		return tx.Create(proof(affected)).Error
+	})
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return affected, nil
+}
+
+// UserSortColumns maps the sortBy values accepted by FindAll to columns.
+var UserSortColumns = map[string]string{
+	"name":      "users.name",
+	"username":  "users.user_name",
+	"email":     "users.email",
+	"createdAt": "users.created_at",
+}
+
+// FindAll returns one page of users matching the filters together with the
+// total number of matches. With a cursor the page starts after the cursor's
+// row (keyset pagination); otherwise Page is used as an offset.
+func (r *UserRepository) FindAll(ctx context.Context, req *dto.UserListRequest, cursor *dto.UserCursor) ([]models.User, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.User{})
+
+	if req.Search != "" {
+		pattern := "%" + escapeLike(req.Search) + "%"
+		query = query.Where(
+			"users.name ILIKE @pattern OR users.user_name ILIKE @pattern OR users.email ILIKE @pattern OR users.phone_number ILIKE @pattern",
+			sql.Named("pattern", pattern),
+		)
+	}
+	if req.Role != "" {
+		query = query.
+			Joins("JOIN roles ON roles.id = users.role_id").
+			Where("LOWER(roles.code) = LOWER(?)", req.Role)
+	}
+	if req.Status != "" {
+		query = query.Where("users.status = ?", req.Status)
+	}
+	if !req.CreatedFrom.IsZero() {
+		query = query.Where("users.created_at >= ?", req.CreatedFrom)
+	}
+	if !req.CreatedTo.IsZero() {
+		query = query.Where("users.created_at <= ?", req.CreatedTo)
+	}
+
+	var total int64
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+
+	column := UserSortColumns[req.SortBy]
+	direction := "ASC"
+	comparator := ">"
+	if req.SortOrder == "desc" {
+		direction = "DESC"
+		comparator = "<"
+	}
+
+	if cursor != nil {
+		var value any = cursor.Value
+		if req.SortBy == "createdAt" {
+			value, err = time.Parse(time.RFC3339Nano, cursor.Value)
+			if err != nil {
+				return nil, 0, errConstant.ErrInvalidCursor
+			}
+		}
+		query = query.Where(fmt.Sprintf("(%s, users.id) %s (?, ?)", column, comparator), value, cursor.ID)
+	} else {
+		query = query.Offset((req.Page - 1) * req.Limit)
+	}
+
+	var users []models.User
+	err = query.
+		Preload("Role").
+		Order(fmt.Sprintf("%s %s, users.id %s", column, direction, direction)).
+		Limit(req.Limit).
+		Find(&users).Error
+	if err != nil {
+		return nil, 0, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return users, total, nil
+}
+
+// uniqueViolation maps a violated unique index on users to the matching domain
+// error, so a registration that loses a race fails like the pre-insert check.
+func uniqueViolation(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != pgUniqueViolation {
+		return nil
+	}
+
+	switch pgErr.ConstraintName {
+	case models.UserUserNameIndex:
+		return errConstant.ErrUsernameExist
+	case models.UserEmailIndex:
+		return errConstant.ErrEmailExist
+	}
+	return nil
+}
+
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
 // func (r *UserRepository) Preload(column string) *gorm.DB {
 // 	return r.db.Preload(column)
 // }
@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+	"user-service/domain/models"
+
+	errWrap "user-service/common/error"
+	errConstant "user-service/constants/error"
+
+	"gorm.io/gorm"
+)
+
+type UserTokenRepository struct {
+	db *gorm.DB
+}
+
+type IUserTokenRepository interface {
+	Create(context.Context, *models.UserToken) (*models.UserToken, error)
+	FindValid(context.Context, string, string) (*models.UserToken, error)
+	MarkUsed(context.Context, uint) (bool, error)
+	InvalidateByUserID(context.Context, uint, string) error
+	FindLatest(context.Context, uint, string) (*models.UserToken, error)
+}
+
+func NewUserTokenRepository(db *gorm.DB) IUserTokenRepository {
+	return &UserTokenRepository{db: db}
+}
+
+func (r *UserTokenRepository) Create(ctx context.Context, token *models.UserToken) (*models.UserToken, error) {
+	err := r.db.WithContext(ctx).Create(token).Error
+	if err != nil {
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return token, nil
+}
+
+// FindValid returns the unused, unexpired token with the given purpose and hash.
+func (r *UserTokenRepository) FindValid(ctx context.Context, purpose, hash string) (*models.UserToken, error) {
+	var token models.UserToken
+	err := r.db.WithContext(ctx).
+		Where("purpose = ? AND token_hash = ? AND used_at IS NULL AND expires_at > ?", purpose, hash, time.Now()).
+		First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errConstant.ErrInvalidUserToken
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return &token, nil
+}
+
+// MarkUsed consumes the token and reports whether it was still unused, so a
+// token can only be redeemed once even under concurrent requests.
+func (r *UserTokenRepository) MarkUsed(ctx context.Context, id uint) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Model(&models.UserToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// InvalidateByUserID consumes every outstanding token of the user for the
+// purpose, so only the most recently issued one can be used.
+func (r *UserTokenRepository) InvalidateByUserID(ctx context.Context, userID uint, purpose string) error {
+	err := r.db.WithContext(ctx).
+		Model(&models.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", time.Now()).Error
+	if err != nil {
+		return errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return nil
+}
+
+// FindLatest returns the most recently issued token of the user for the
+// purpose, or nil when none was ever issued.
+func (r *UserTokenRepository) FindLatest(ctx context.Context, userID uint, purpose string) (*models.UserToken, error) {
+	var token models.UserToken
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND purpose = ?", userID, purpose).
+		Order("created_at DESC").
+		First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, errWrap.WrapError(errConstant.ErrSQLError)
+	}
+	return &token, nil
+}
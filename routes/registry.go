@@ -2,14 +2,17 @@ package routes
 
 import (
 	"user-service/controllers"
+	"user-service/services"
 
 	"github.com/gin-gonic/gin"
 
+	serviceClientRoutes "user-service/routes/serviceclient"
 	routes "user-service/routes/user"
 )
 
 type Registry struct {
 	controller controllers.IControllerRegistry
+	service    services.IServiceRegistry
 	group      *gin.RouterGroup
 }
 
@@ -17,14 +20,19 @@ type IRouteRegistry interface {
 	Serve()
 }
 
-func NewRouteRegsitry(controller controllers.IControllerRegistry, group *gin.RouterGroup) IRouteRegistry {
-	return &Registry{controller: controller, group: group}
+func NewRouteRegsitry(controller controllers.IControllerRegistry, service services.IServiceRegistry, group *gin.RouterGroup) IRouteRegistry {
+	return &Registry{controller: controller, service: service, group: group}
 }
 
 func (r *Registry) Serve() {
 	r.userRoute().Run()
+	r.serviceClientRoute().Run()
 }
 
 func (r *Registry) userRoute() routes.IUserRoute {
-	return routes.NewUserRoute(r.controller, r.group)
+	return routes.NewUserRoute(r.controller, r.service, r.group)
+}
+
+func (r *Registry) serviceClientRoute() serviceClientRoutes.IServiceClientRoute {
+	return serviceClientRoutes.NewServiceClientRoute(r.controller, r.service, r.group)
 }
@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"user-service/constants"
+	"user-service/controllers"
+	"user-service/middlewares"
+	"user-service/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ServiceClientRoute struct {
+	controller controllers.IControllerRegistry
+	service    services.IServiceRegistry
+	group      *gin.RouterGroup
+}
+
+type IServiceClientRoute interface {
+	Run()
+}
+
+func NewServiceClientRoute(controller controllers.IControllerRegistry, service services.IServiceRegistry, group *gin.RouterGroup) IServiceClientRoute {
+	return &ServiceClientRoute{controller: controller, service: service, group: group}
+}
+
+func (s *ServiceClientRoute) Run() {
+	group := s.group.Group("/service-clients")
+	group.Use(
+		middlewares.Authenticate(s.service),
+		middlewares.RequireRole(constants.RoleAdmin),
+		middlewares.RequirePermission(s.service, constants.PermissionServiceClientManage),
+	)
+	group.GET("", s.controller.GetServiceClientController().GetServiceClients)
+	group.POST("", s.controller.GetServiceClientController().IssueServiceClient)
+	group.POST("/:name/rotate", s.controller.GetServiceClientController().RotateServiceClient)
+	group.POST("/:name/revoke", s.controller.GetServiceClientController().RevokeServiceClient)
+}
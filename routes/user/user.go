@@ -1,14 +1,17 @@
 package routes
 
 import (
+	"user-service/constants"
 	"user-service/controllers"
 	"user-service/middlewares"
+	"user-service/services"
 
 	"github.com/gin-gonic/gin"
 )
 
 type UserRoute struct {
 	controller controllers.IControllerRegistry
+	service    services.IServiceRegistry
 	group      *gin.RouterGroup
 }
 
@@ -16,15 +19,79 @@ type IUserRoute interface {
 	Run()
 }
 
-func NewUserRoute(controller controllers.IControllerRegistry, group *gin.RouterGroup) IUserRoute {
-	return &UserRoute{controller: controller, group: group}
+func NewUserRoute(controller controllers.IControllerRegistry, service services.IServiceRegistry, group *gin.RouterGroup) IUserRoute {
+	return &UserRoute{controller: controller, service: service, group: group}
 }
 
 func (u *UserRoute) Run() {
 	group := u.group.Group("/auth")
-	group.GET("/user", middlewares.Authenticate(), u.controller.GetUserController().GetUserLogin)
-	group.GET("/:uuid", middlewares.Authenticate(), u.controller.GetUserController().GetUserByUUID)
+	group.GET("/user", middlewares.AuthenticateAllowPasswordChange(u.service), u.controller.GetUserController().GetUserLogin)
+	group.GET("/:uuid",
+		middlewares.Authenticate(u.service),
+		middlewares.RequirePermission(u.service, constants.PermissionUserRead),
+		u.controller.GetUserController().GetUserByUUID,
+	)
 	group.POST("/login", u.controller.GetUserController().Login)
+	group.POST("/login/mfa", u.controller.GetUserController().LoginMFA)
 	group.POST("/register", u.controller.GetUserController().Register)
-	group.PUT("/:uuid", middlewares.Authenticate(), u.controller.GetUserController().Update)
+	group.POST("/refresh", u.controller.GetUserController().Refresh)
+	group.POST("/forgot-password", u.controller.GetUserController().ForgotPassword)
+	group.POST("/reset-password", u.controller.GetUserController().ResetPassword)
+	group.GET("/verify-email", u.controller.GetUserController().VerifyEmail)
+	group.POST("/verify-email", u.controller.GetUserController().VerifyEmail)
+	group.POST("/verify-email/resend", u.controller.GetUserController().ResendEmailVerification)
+	group.POST("/logout", middlewares.AuthenticateAllowPasswordChange(u.service), u.controller.GetUserController().Logout)
+	group.POST("/logout-all", middlewares.AuthenticateAllowPasswordChange(u.service), u.controller.GetUserController().LogoutAll)
+	group.POST("/change-password",
+		middlewares.AuthenticateAllowPasswordChange(u.service),
+		u.controller.GetUserController().ChangePassword,
+	)
+	group.POST("/mfa/enroll", middlewares.Authenticate(u.service), u.controller.GetUserController().EnrollMFA)
+	group.POST("/mfa/confirm", middlewares.Authenticate(u.service), u.controller.GetUserController().ConfirmMFA)
+	group.POST("/mfa/disable", middlewares.Authenticate(u.service), u.controller.GetUserController().DisableMFA)
+	group.POST("/close-account", middlewares.Authenticate(u.service), u.controller.GetUserController().CloseAccount)
+	group.PUT("/:uuid",
+		middlewares.Authenticate(u.service),
+		middlewares.RequirePermission(u.service, constants.PermissionUserUpdate),
+		u.controller.GetUserController().Update,
+	)
+
+	// Internal routes are called by other services without a user; the
+	// service's scopes decide which of them it may use. /internal/users/:uuid
+	// is the service-only counterpart of /auth/:uuid.
+	internal := u.group.Group("/internal")
+	internal.Use(middlewares.AuthenticateService(u.service))
+	internal.GET("/users/:uuid", u.controller.GetUserController().GetUserByUUID)
+
+	u.group.GET("/users/me/export", middlewares.Authenticate(u.service), u.controller.GetUserController().ExportUserData)
+
+	users := u.group.Group("/users")
+	users.Use(
+		middlewares.Authenticate(u.service),
+		middlewares.RequireRole(constants.RoleAdmin),
+	)
+	users.GET("",
+		middlewares.RequirePermission(u.service, constants.PermissionUserList),
+		u.controller.GetUserController().GetUsers,
+	)
+	users.POST("/:uuid/unlock",
+		middlewares.RequirePermission(u.service, constants.PermissionUserManage),
+		u.controller.GetUserController().UnlockUser,
+	)
+	users.POST("/:uuid/suspend",
+		middlewares.RequirePermission(u.service, constants.PermissionUserManage),
+		u.controller.GetUserController().SuspendUser,
+	)
+	users.POST("/:uuid/reactivate",
+		middlewares.RequirePermission(u.service, constants.PermissionUserManage),
+		u.controller.GetUserController().ReactivateUser,
+	)
+	users.DELETE("/:uuid",
+		middlewares.RequirePermission(u.service, constants.PermissionUserManage),
+		u.controller.GetUserController().DeleteUser,
+	)
+	users.POST("/:uuid/erase",
+		middlewares.RequirePermission(u.service, constants.PermissionUserManage),
+		u.controller.GetUserController().EraseUser,
+	)
 }
@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"user-service/common/apikey"
+	"user-service/common/logger"
+	"user-service/common/secretbox"
+	"user-service/config"
+	errConstant "user-service/constants/error"
+	"user-service/domain/dto"
+	"user-service/domain/models"
+	"user-service/repositories"
+
+	"github.com/patrickmn/go-cache"
+)
+
+const (
+	defaultClockSkewSeconds = 60
+	defaultCacheTTLSeconds  = 30
+	minNonceLength          = 16
+	maxNonceLength          = 128
+	lastUsedInterval        = time.Minute
+	scopeAll                = "*"
+)
+
+type APIKeyService struct {
+	repository repositories.IRepositoryRegistry
+	clockSkew  time.Duration
+	clients    *cache.Cache
+	lastUsed   *cache.Cache
+}
+
+type IAPIKeyService interface {
+	Verify(ctx context.Context, req *apikey.Request, signature, route string) (*models.ServiceClient, error)
+	ClientSecret(ctx context.Context, name string) (string, error)
+	IssueClient(ctx context.Context, req *dto.ServiceClientRequest) (*dto.ServiceClientSecretResponse, error)
+	RotateClient(ctx context.Context, name string, req *dto.RotateServiceClientRequest) (*dto.ServiceClientSecretResponse, error)
+	RevokeClient(ctx context.Context, name string) error
+	GetClients(ctx context.Context) ([]dto.ServiceClientResponse, error)
+	PurgeExpiredNonces(ctx context.Context) (int64, error)
+}
+
+// NewAPIKeyService caches service clients in memory, so it should be created
+// once and shared. A revoked or rotated client is picked up by other replicas
+// once their cache entry expires. Nonces are stored in the database so that a
+// request accepted by one replica cannot be replayed against another.
+func NewAPIKeyService(repository repositories.IRepositoryRegistry) IAPIKeyService {
+	skew := config.Config.APIKeyClockSkewSeconds
+	if skew <= 0 {
+		skew = defaultClockSkewSeconds
+	}
+	ttl := config.Config.ServiceClientCacheTTLSeconds
+	if ttl <= 0 {
+		ttl = defaultCacheTTLSeconds
+	}
+	clockSkew := time.Duration(skew) * time.Second
+	expiration := time.Duration(ttl) * time.Second
+	return &APIKeyService{
+		repository: repository,
+		clockSkew:  clockSkew,
+		clients:    cache.New(expiration, 2*expiration),
+		lastUsed:   cache.New(lastUsedInterval, 2*lastUsedInterval),
+	}
+}
+
+// Verify accepts a signed request once, and only while its timestamp is within
+// the clock skew window. The signature must be made with the calling service's
+// own secret, or its previous one during a rotation, and the route must be in
+// the service's scopes. A nonce is kept for twice the window, which covers
+// every timestamp that would still be accepted.
+func (a *APIKeyService) Verify(ctx context.Context, req *apikey.Request, signature, route string) (*models.ServiceClient, error) {
+	if req.ServiceName == "" || signature == "" {
+		return nil, errConstant.ErrInvalidAPIKey
+	}
+	if len(req.Nonce) < minNonceLength || len(req.Nonce) > maxNonceLength {
+		return nil, errConstant.ErrInvalidAPIKey
+	}
+
+	requestAt, err := strconv.ParseInt(req.RequestAt, 10, 64)
+	if err != nil {
+		return nil, errConstant.ErrInvalidAPIKey
+	}
+	age := time.Since(time.Unix(requestAt, 0))
+	if age > a.clockSkew || age < -a.clockSkew {
+		return nil, errConstant.ErrAPIKeyExpired
+	}
+
+	client, err := a.findClient(ctx, req.ServiceName)
+	if err != nil {
+		if errors.Is(err, errConstant.ErrServiceClientNotFound) {
+			return nil, errConstant.ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+	if !client.Enabled {
+		return nil, errConstant.ErrInvalidAPIKey
+	}
+
+	valid, err := verifySignature(client, req, signature)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, errConstant.ErrInvalidAPIKey
+	}
+
+	if !allowsRoute(client.Scopes, req.Method, route) {
+		return nil, errConstant.ErrForbidden
+	}
+
+	unused, err := a.repository.GetAPIKeyNonce().Use(ctx, req.ServiceName, req.Nonce, time.Now().Add(2*a.clockSkew))
+	if err != nil {
+		return nil, err
+	}
+	if !unused {
+		return nil, errConstant.ErrAPIKeyReplayed
+	}
+
+	a.touch(ctx, client)
+	return client, nil
+}
+
+func (a *APIKeyService) findClient(ctx context.Context, name string) (*models.ServiceClient, error) {
+	if cached, found := a.clients.Get(name); found {
+		return cached.(*models.ServiceClient), nil
+	}
+
+	client, err := a.repository.GetServiceClient().FindByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	a.clients.SetDefault(name, client)
+	return client, nil
+}
+
+func verifySignature(client *models.ServiceClient, req *apikey.Request, signature string) (bool, error) {
+	secret, err := secretbox.Open(config.Config.EncryptionKey, client.Secret)
+	if err != nil {
+		return false, err
+	}
+	if apikey.Verify(secret, req, signature) {
+		return true, nil
+	}
+
+	if client.PreviousSecret == nil || client.PreviousSecretExpiresAt == nil || time.Now().After(*client.PreviousSecretExpiresAt) {
+		return false, nil
+	}
+	previous, err := secretbox.Open(config.Config.EncryptionKey, *client.PreviousSecret)
+	if err != nil {
+		return false, err
+	}
+	return apikey.Verify(previous, req, signature), nil
+}
+
+// allowsRoute matches "METHOD /route" scopes, where the route is the pattern
+// it was registered with (such as /api/v1/auth/:uuid), and "*" for any route.
+func allowsRoute(scopes []string, method, route string) bool {
+	target := strings.ToUpper(method) + " " + route
+	for _, scope := range scopes {
+		if scope == scopeAll || scope == target {
+			return true
+		}
+	}
+	return false
+}
+
+// PurgeExpiredNonces deletes nonces whose requests can no longer be accepted.
+// serve runs it periodically.
+func (a *APIKeyService) PurgeExpiredNonces(ctx context.Context) (int64, error) {
+	return a.repository.GetAPIKeyNonce().DeleteExpired(ctx, time.Now())
+}
+
+// touch records when the client was last used, at most once a minute.
+func (a *APIKeyService) touch(ctx context.Context, client *models.ServiceClient) {
+	if a.lastUsed.Add(client.Name, struct{}{}, lastUsedInterval) != nil {
+		return
+	}
+
+	err := a.repository.GetServiceClient().UpdateLastUsedAt(ctx, client.ID, time.Now())
+	if err != nil {
+		logger.FromContext(ctx).Errorf("failed to record last use of service client %s: %v", client.Name, err)
+	}
+}
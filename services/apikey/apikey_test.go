@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+	"user-service/common/apikey"
+	"user-service/common/secretbox"
+	"user-service/config"
+	errConstant "user-service/constants/error"
+	"user-service/domain/models"
+	"user-service/repositories"
+	apiKeyNonceRepositories "user-service/repositories/apikeynonce"
+	serviceClientRepositories "user-service/repositories/serviceclient"
+)
+
+const (
+	testService = "order-service"
+	testSecret  = "order-service-secret"
+	testRoute   = "/api/v1/internal/users/:uuid"
+)
+
+type serviceClientRepository struct {
+	serviceClientRepositories.IServiceClientRepository
+	client *models.ServiceClient
+}
+
+func (r *serviceClientRepository) FindByName(_ context.Context, name string) (*models.ServiceClient, error) {
+	if r.client == nil || r.client.Name != name {
+		return nil, errConstant.ErrServiceClientNotFound
+	}
+	return r.client, nil
+}
+
+func (r *serviceClientRepository) UpdateLastUsedAt(context.Context, uint, time.Time) error {
+	return nil
+}
+
+// nonceRepository stands in for the api_key_nonces table, which every replica
+// shares.
+type nonceRepository struct {
+	apiKeyNonceRepositories.IAPIKeyNonceRepository
+	used map[string]time.Time
+}
+
+func (r *nonceRepository) Use(_ context.Context, serviceName, nonce string, expiresAt time.Time) (bool, error) {
+	key := serviceName + ":" + nonce
+	if expires, ok := r.used[key]; ok && time.Now().Before(expires) {
+		return false, nil
+	}
+	r.used[key] = expiresAt
+	return true, nil
+}
+
+type repositoryRegistry struct {
+	repositories.IRepositoryRegistry
+	clients *serviceClientRepository
+	nonces  *nonceRepository
+}
+
+func (r *repositoryRegistry) GetServiceClient() serviceClientRepositories.IServiceClientRepository {
+	return r.clients
+}
+
+func (r *repositoryRegistry) GetAPIKeyNonce() apiKeyNonceRepositories.IAPIKeyNonceRepository {
+	return r.nonces
+}
+
+func newTestRegistry(t *testing.T) *repositoryRegistry {
+	t.Helper()
+	config.Config.EncryptionKey = "test-encryption-key"
+	config.Config.APIKeyClockSkewSeconds = 60
+
+	sealed, err := secretbox.Seal(config.Config.EncryptionKey, testSecret)
+	if err != nil {
+		t.Fatalf("seal secret: %v", err)
+	}
+	return &repositoryRegistry{
+		clients: &serviceClientRepository{client: &models.ServiceClient{
+			ID:      1,
+			Name:    testService,
+			Secret:  sealed,
+			Scopes:  []string{"GET " + testRoute},
+			Enabled: true,
+		}},
+		nonces: &nonceRepository{used: map[string]time.Time{}},
+	}
+}
+
+func signedRequest(t *testing.T, body string, at time.Time) (*apikey.Request, string) {
+	t.Helper()
+	nonce, err := apikey.NewNonce()
+	if err != nil {
+		t.Fatalf("nonce: %v", err)
+	}
+	req := &apikey.Request{
+		ServiceName: testService,
+		Method:      "GET",
+		Path:        "/api/v1/internal/users/4bf92f35-77b3-4da6-a3ce-929d0e0e4736",
+		RequestAt:   strconv.FormatInt(at.Unix(), 10),
+		BodyHash:    apikey.HashBody([]byte(body)),
+		Nonce:       nonce,
+	}
+	return req, apikey.Sign(testSecret, req)
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(req *apikey.Request, signature string) string
+		at      time.Duration
+		wantErr error
+	}{
+		{
+			name: "valid request",
+		},
+		{
+			name: "tampered body",
+			prepare: func(req *apikey.Request, signature string) string {
+				req.BodyHash = apikey.HashBody([]byte(`{"role":"admin"}`))
+				return signature
+			},
+			wantErr: errConstant.ErrInvalidAPIKey,
+		},
+		{
+			name: "tampered path",
+			prepare: func(req *apikey.Request, signature string) string {
+				req.Path += "?expand=all"
+				return signature
+			},
+			wantErr: errConstant.ErrInvalidAPIKey,
+		},
+		{
+			name: "signed with another secret",
+			prepare: func(req *apikey.Request, _ string) string {
+				return apikey.Sign("another-secret", req)
+			},
+			wantErr: errConstant.ErrInvalidAPIKey,
+		},
+		{
+			name:    "timestamp past the clock skew",
+			at:      -2 * time.Minute,
+			wantErr: errConstant.ErrAPIKeyExpired,
+		},
+		{
+			name:    "timestamp ahead of the clock skew",
+			at:      2 * time.Minute,
+			wantErr: errConstant.ErrAPIKeyExpired,
+		},
+		{
+			name: "timestamp within the clock skew",
+			at:   -50 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewAPIKeyService(newTestRegistry(t))
+			req, signature := signedRequest(t, `{"name":"jane"}`, time.Now().Add(tt.at))
+			if tt.prepare != nil {
+				signature = tt.prepare(req, signature)
+			}
+
+			client, err := service.Verify(context.Background(), req, signature, testRoute)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && client.Name != testService {
+				t.Errorf("Verify client = %q, want %q", client.Name, testService)
+			}
+		})
+	}
+}
+
+func TestVerifyRejectsReplay(t *testing.T) {
+	registry := newTestRegistry(t)
+	req, signature := signedRequest(t, "", time.Now())
+
+	_, err := NewAPIKeyService(registry).Verify(context.Background(), req, signature, testRoute)
+	if err != nil {
+		t.Fatalf("first request: %v", err)
+	}
+
+	// A second service instance shares only the nonce store, like another
+	// replica would.
+	_, err = NewAPIKeyService(registry).Verify(context.Background(), req, signature, testRoute)
+	if !errors.Is(err, errConstant.ErrAPIKeyReplayed) {
+		t.Fatalf("replay error = %v, want %v", err, errConstant.ErrAPIKeyReplayed)
+	}
+}
+
+func TestVerifyRejectsRouteOutsideScopes(t *testing.T) {
+	req, signature := signedRequest(t, "", time.Now())
+
+	_, err := NewAPIKeyService(newTestRegistry(t)).Verify(context.Background(), req, signature, "/api/v1/users")
+	if !errors.Is(err, errConstant.ErrForbidden) {
+		t.Fatalf("Verify error = %v, want %v", err, errConstant.ErrForbidden)
+	}
+}
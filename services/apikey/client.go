@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"user-service/common/apikey"
+	"user-service/common/logger"
+	"user-service/common/secretbox"
+	"user-service/config"
+	"user-service/constants"
+	errConstant "user-service/constants/error"
+	"user-service/domain/dto"
+	"user-service/domain/models"
+
+	"github.com/google/uuid"
+)
+
+const defaultRotationOverlap = 24 * 60
+
+var scopePattern = regexp.MustCompile(`^(GET|POST|PUT|PATCH|DELETE) /\S*$`)
+
+func toServiceClientResponse(client *models.ServiceClient) dto.ServiceClientResponse {
+	return dto.ServiceClientResponse{
+		UUID:                    client.UUID,
+		Name:                    client.Name,
+		Scopes:                  client.Scopes,
+		Enabled:                 client.Enabled,
+		PreviousSecretExpiresAt: client.PreviousSecretExpiresAt,
+		LastUsedAt:              client.LastUsedAt,
+		RevokedAt:               client.RevokedAt,
+		CreatedAt:               client.CreatedAt,
+	}
+}
+
+func normalizeScopes(scopes []string) ([]string, error) {
+	normalized := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if scope != scopeAll {
+			method, route, _ := strings.Cut(scope, " ")
+			scope = strings.ToUpper(method) + " " + strings.TrimSpace(route)
+			if !scopePattern.MatchString(scope) {
+				return nil, errConstant.ErrInvalidServiceScope
+			}
+		}
+		normalized = append(normalized, scope)
+	}
+	return normalized, nil
+}
+
+func newSealedSecret() (string, string, error) {
+	secret, err := apikey.NewSecret()
+	if err != nil {
+		return "", "", err
+	}
+
+	sealed, err := secretbox.Seal(config.Config.EncryptionKey, secret)
+	if err != nil {
+		return "", "", err
+	}
+	return secret, sealed, nil
+}
+
+func (a *APIKeyService) recordAudit(ctx context.Context, client *models.ServiceClient, action string) {
+	var actorUUID *uuid.UUID
+	if userLogin, ok := ctx.Value(constants.UserLogin).(*dto.UserResponse); ok && userLogin != nil {
+		actorUUID = &userLogin.UUID
+	}
+
+	err := a.repository.GetAudit().Create(ctx, &models.AuditLog{
+		ActorUUID:  actorUUID,
+		Action:     action,
+		TargetUUID: &client.UUID,
+		Outcome:    constants.AuditOutcomeSuccess,
+	})
+	if err != nil {
+		logger.FromContext(ctx).Errorf("failed to record %s of service client %s: %v", action, client.Name, err)
+	}
+}
+
+// ClientSecret returns the current plaintext secret of an enabled client.
+func (a *APIKeyService) ClientSecret(ctx context.Context, name string) (string, error) {
+	client, err := a.repository.GetServiceClient().FindByName(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if !client.Enabled {
+		return "", errConstant.ErrServiceClientRevoked
+	}
+	return secretbox.Open(config.Config.EncryptionKey, client.Secret)
+}
+
+// IssueClient creates a client with a new secret. A revoked client can be
+// issued again under the same name; an enabled one has to be rotated instead.
+func (a *APIKeyService) IssueClient(ctx context.Context, req *dto.ServiceClientRequest) (*dto.ServiceClientSecretResponse, error) {
+	scopes, err := normalizeScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, sealed, err := newSealedSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := a.repository.GetServiceClient().FindByName(ctx, req.Name)
+	switch {
+	case errors.Is(err, errConstant.ErrServiceClientNotFound):
+		client = &models.ServiceClient{
+			UUID:    uuid.New(),
+			Name:    req.Name,
+			Secret:  sealed,
+			Scopes:  scopes,
+			Enabled: true,
+		}
+		err = a.repository.GetServiceClient().Create(ctx, client)
+	case err != nil:
+		return nil, err
+	case client.Enabled:
+		return nil, errConstant.ErrServiceClientExist
+	default:
+		client.Secret = sealed
+		client.PreviousSecret = nil
+		client.PreviousSecretExpiresAt = nil
+		client.Scopes = scopes
+		client.Enabled = true
+		client.RevokedAt = nil
+		err = a.repository.GetServiceClient().UpdateCredentials(ctx, client)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	a.clients.Delete(client.Name)
+	a.recordAudit(ctx, client, constants.AuditActionServiceClientIssue)
+	return &dto.ServiceClientSecretResponse{
+		ServiceClientResponse: toServiceClientResponse(client),
+		Secret:                secret,
+	}, nil
+}
+
+// RotateClient replaces the client's secret. The old secret keeps working for
+// the overlap, so the caller can be redeployed with the new one first.
+func (a *APIKeyService) RotateClient(ctx context.Context, name string, req *dto.RotateServiceClientRequest) (*dto.ServiceClientSecretResponse, error) {
+	client, err := a.repository.GetServiceClient().FindByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if !client.Enabled {
+		return nil, errConstant.ErrServiceClientRevoked
+	}
+
+	overlap := config.Config.ServiceClientRotationOverlap
+	if overlap <= 0 {
+		overlap = defaultRotationOverlap
+	}
+	if req.OverlapMinutes != nil {
+		overlap = *req.OverlapMinutes
+	}
+
+	secret, sealed, err := newSealedSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	client.PreviousSecret = nil
+	client.PreviousSecretExpiresAt = nil
+	if overlap > 0 {
+		previous := client.Secret
+		expiresAt := time.Now().Add(time.Duration(overlap) * time.Minute)
+		client.PreviousSecret = &previous
+		client.PreviousSecretExpiresAt = &expiresAt
+	}
+	client.Secret = sealed
+
+	err = a.repository.GetServiceClient().UpdateCredentials(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	a.clients.Delete(client.Name)
+	a.recordAudit(ctx, client, constants.AuditActionServiceClientRotate)
+	return &dto.ServiceClientSecretResponse{
+		ServiceClientResponse: toServiceClientResponse(client),
+		Secret:                secret,
+	}, nil
+}
+
+// RevokeClient disables the client, which rejects both of its secrets.
+func (a *APIKeyService) RevokeClient(ctx context.Context, name string) error {
+	client, err := a.repository.GetServiceClient().FindByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !client.Enabled {
+		return errConstant.ErrServiceClientRevoked
+	}
+
+	now := time.Now()
+	client.PreviousSecret = nil
+	client.PreviousSecretExpiresAt = nil
+	client.Enabled = false
+	client.RevokedAt = &now
+
+	err = a.repository.GetServiceClient().UpdateCredentials(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	a.clients.Delete(client.Name)
+	a.recordAudit(ctx, client, constants.AuditActionServiceClientRevoke)
+	return nil
+}
+
+func (a *APIKeyService) GetClients(ctx context.Context) ([]dto.ServiceClientResponse, error) {
+	clients, err := a.repository.GetServiceClient().FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]dto.ServiceClientResponse, 0, len(clients))
+	for i := range clients {
+		result = append(result, toServiceClientResponse(&clients[i]))
+	}
+	return result, nil
+}
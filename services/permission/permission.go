@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+	"user-service/config"
+	"user-service/repositories"
+
+	"github.com/patrickmn/go-cache"
+)
+
+const defaultCacheTTLSeconds = 60
+
+type PermissionService struct {
+	repository repositories.IRepositoryRegistry
+	cache      *cache.Cache
+}
+
+type IPermissionService interface {
+	HasPermissions(ctx context.Context, role string, permissions ...string) (bool, error)
+}
+
+// NewPermissionService caches the permissions of each role in memory, so it
+// should be created once and shared.
+func NewPermissionService(repository repositories.IRepositoryRegistry) IPermissionService {
+	ttl := config.Config.PermissionCacheTTLSeconds
+	if ttl <= 0 {
+		ttl = defaultCacheTTLSeconds
+	}
+	expiration := time.Duration(ttl) * time.Second
+	return &PermissionService{
+		repository: repository,
+		cache:      cache.New(expiration, 2*expiration),
+	}
+}
+
+// HasPermissions reports whether the role is granted every given permission.
+func (p *PermissionService) HasPermissions(ctx context.Context, role string, permissions ...string) (bool, error) {
+	granted, err := p.rolePermissions(ctx, role)
+	if err != nil {
+		return false, err
+	}
+
+	for _, permission := range permissions {
+		if !granted[permission] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (p *PermissionService) rolePermissions(ctx context.Context, role string) (map[string]bool, error) {
+	key := strings.ToLower(role)
+	if cached, found := p.cache.Get(key); found {
+		return cached.(map[string]bool), nil
+	}
+
+	codes, err := p.repository.GetPermission().FindCodesByRoleCode(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+
+	granted := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		granted[code] = true
+	}
+
+	p.cache.SetDefault(key, granted)
+	return granted, nil
+}
@@ -1,22 +1,51 @@
 package services
 
 import (
+	"user-service/clients/notifier"
 	"user-service/repositories"
+	apiKeyServices "user-service/services/apikey"
+	permissionServices "user-service/services/permission"
+	revocationServices "user-service/services/revocation"
 	services "user-service/services/user"
 )
 
 type Registry struct {
 	repository repositories.IRepositoryRegistry
+	revocation revocationServices.IRevocationService
+	permission permissionServices.IPermissionService
+	apiKey     apiKeyServices.IAPIKeyService
+	notifier   notifier.INotifier
 }
 
 type IServiceRegistry interface {
 	GetUser() services.IUserService
+	GetRevocation() revocationServices.IRevocationService
+	GetPermission() permissionServices.IPermissionService
+	GetAPIKey() apiKeyServices.IAPIKeyService
 }
 
-func NewServiceRegistry(repository repositories.IRepositoryRegistry) IServiceRegistry {
-	return &Registry{repository: repository}
+func NewServiceRegistry(repository repositories.IRepositoryRegistry, notifier notifier.INotifier) IServiceRegistry {
+	return &Registry{
+		repository: repository,
+		notifier:   notifier,
+		revocation: revocationServices.NewRevocationService(repository),
+		permission: permissionServices.NewPermissionService(repository),
+		apiKey:     apiKeyServices.NewAPIKeyService(repository),
+	}
 }
 
 func (r *Registry) GetUser() services.IUserService {
-	return services.NewUserService(r.repository)
+	return services.NewTracedUserService(services.NewUserService(r.repository, r.revocation, r.notifier))
+}
+
+func (r *Registry) GetRevocation() revocationServices.IRevocationService {
+	return r.revocation
+}
+
+func (r *Registry) GetPermission() permissionServices.IPermissionService {
+	return r.permission
+}
+
+func (r *Registry) GetAPIKey() apiKeyServices.IAPIKeyService {
+	return r.apiKey
 }
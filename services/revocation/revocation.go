@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"user-service/config"
+	"user-service/constants"
+	errConstant "user-service/constants/error"
+	"user-service/domain/models"
+	"user-service/repositories"
+
+	"github.com/google/uuid"
+	"github.com/patrickmn/go-cache"
+)
+
+const defaultCacheTTLSeconds = 30
+
+type RevocationService struct {
+	repository repositories.IRepositoryRegistry
+	cache      *cache.Cache
+}
+
+type IRevocationService interface {
+	RevokeToken(ctx context.Context, jti string, userUUID uuid.UUID, expiresAt time.Time) error
+	RevokeAllForUser(ctx context.Context, userUUID uuid.UUID) error
+	IsRevoked(ctx context.Context, jti string, userUUID uuid.UUID, issuedAt time.Time) (bool, error)
+	IsUserActive(ctx context.Context, userUUID uuid.UUID) (bool, error)
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+// NewRevocationService returns a revocation store backed by the database. Only
+// revoked tokens are cached in memory, since a revocation never goes away; a
+// token that is not revoked, and the revoke-all cutoff of a user, are read from
+// the database on every check so that a revocation made on another replica is
+// seen right away. It holds state, so it should be created once and shared.
+func NewRevocationService(repository repositories.IRepositoryRegistry) IRevocationService {
+	ttl := config.Config.RevocationCacheTTLSeconds
+	if ttl <= 0 {
+		ttl = defaultCacheTTLSeconds
+	}
+	expiration := time.Duration(ttl) * time.Second
+	return &RevocationService{
+		repository: repository,
+		cache:      cache.New(expiration, 2*expiration),
+	}
+}
+
+func tokenKey(jti string) string {
+	return fmt.Sprintf("jti:%s", jti)
+}
+
+func (r *RevocationService) RevokeToken(ctx context.Context, jti string, userUUID uuid.UUID, expiresAt time.Time) error {
+	err := r.repository.GetRevocation().RevokeToken(ctx, &models.RevokedToken{
+		JTI:       jti,
+		UserUUID:  userUUID,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	r.cache.SetDefault(tokenKey(jti), true)
+	return nil
+}
+
+func (r *RevocationService) RevokeAllForUser(ctx context.Context, userUUID uuid.UUID) error {
+	return r.repository.GetRevocation().RevokeAllForUser(ctx, userUUID, time.Now())
+}
+
+// IsRevoked reports whether the token was revoked on its own or by a
+// revoke-all for its user issued after the token.
+func (r *RevocationService) IsRevoked(ctx context.Context, jti string, userUUID uuid.UUID, issuedAt time.Time) (bool, error) {
+	revoked, err := r.isTokenRevoked(ctx, jti)
+	if err != nil || revoked {
+		return revoked, err
+	}
+
+	revokedAt, err := r.userRevokedAt(ctx, userUUID)
+	if err != nil {
+		return false, err
+	}
+
+	// JWT timestamps have second precision, so compare against the cutoff's
+	// second to keep tokens issued right after a revoke-all valid.
+	return revokedAt != nil && issuedAt.Before(revokedAt.Truncate(time.Second)), nil
+}
+
+// IsUserActive reports whether the user still exists, is not deleted and is
+// active. It is read from the database on every call, so a suspended or
+// deleted user is refused even with a token issued in the same second as the
+// revoke-all cutoff.
+func (r *RevocationService) IsUserActive(ctx context.Context, userUUID uuid.UUID) (bool, error) {
+	user, err := r.repository.GetUser().FindByUUIDWithDeleted(ctx, userUUID.String())
+	if err != nil {
+		if errors.Is(err, errConstant.ErrUserNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return user.Status == constants.UserStatusActive && !user.DeletedAt.Valid, nil
+}
+
+func (r *RevocationService) isTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	if _, found := r.cache.Get(tokenKey(jti)); found {
+		return true, nil
+	}
+
+	revoked, err := r.repository.GetRevocation().IsTokenRevoked(ctx, jti)
+	if err != nil {
+		return false, err
+	}
+
+	if revoked {
+		r.cache.SetDefault(tokenKey(jti), true)
+	}
+	return revoked, nil
+}
+
+func (r *RevocationService) userRevokedAt(ctx context.Context, userUUID uuid.UUID) (*time.Time, error) {
+	revocation, err := r.repository.GetRevocation().FindUserRevocation(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if revocation == nil {
+		return nil, nil
+	}
+	return &revocation.RevokedAt, nil
+}
+
+// PurgeExpired deletes revoked tokens that have expired, since an expired
+// token is rejected without being looked up. serve runs it periodically.
+func (r *RevocationService) PurgeExpired(ctx context.Context) (int64, error) {
+	return r.repository.GetRevocation().DeleteExpired(ctx, time.Now())
+}
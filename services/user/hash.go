@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+	"time"
+	"user-service/common/metrics"
+	"user-service/common/tracing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// hashPassword runs bcrypt in its own span and records its duration, as it is
+// the slowest step of registration and password changes.
+func hashPassword(ctx context.Context, password string) ([]byte, error) {
+	_, span := tracing.Start(ctx, "bcrypt.GenerateFromPassword")
+	start := time.Now()
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	metrics.BcryptDuration.WithLabelValues(metrics.BcryptHash).Observe(time.Since(start).Seconds())
+	tracing.End(span, err)
+	return hashed, err
+}
+
+// comparePassword runs the bcrypt check in its own span and records its
+// duration. A mismatch is the expected outcome of a wrong password and is not
+// recorded as a span error.
+func comparePassword(ctx context.Context, hash []byte, password string) error {
+	_, span := tracing.Start(ctx, "bcrypt.CompareHashAndPassword")
+	start := time.Now()
+	err := bcrypt.CompareHashAndPassword(hash, []byte(password))
+	metrics.BcryptDuration.WithLabelValues(metrics.BcryptCompare).Observe(time.Since(start).Seconds())
+	span.End()
+	return err
+}
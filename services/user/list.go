@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"time"
+	errConstant "user-service/constants/error"
+	"user-service/domain/dto"
+	"user-service/domain/models"
+)
+
+const (
+	defaultPage  = 1
+	defaultLimit = 10
+)
+
+func (u *UserService) GetUsers(ctx context.Context, req *dto.UserListRequest) (*dto.UserListResponse, error) {
+	if req.Page <= 0 {
+		req.Page = defaultPage
+	}
+	if req.Limit <= 0 {
+		req.Limit = defaultLimit
+	}
+	if req.SortBy == "" {
+		req.SortBy = "createdAt"
+		if req.SortOrder == "" {
+			req.SortOrder = "desc"
+		}
+	}
+
+	var cursor *dto.UserCursor
+	if req.Cursor != "" {
+		decoded, err := decodeUserCursor(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		cursor = decoded
+	}
+
+	users, total, err := u.repository.GetUser().FindAll(ctx, req, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	pagination := dto.Pagination{
+		Limit:     req.Limit,
+		TotalData: total,
+	}
+	if cursor == nil {
+		pagination.Page = req.Page
+		pagination.TotalPage = int(math.Ceil(float64(total) / float64(req.Limit)))
+	}
+	if len(users) == req.Limit {
+		pagination.NextCursor = encodeUserCursor(&users[len(users)-1], req.SortBy)
+	}
+
+	data := make([]dto.UserResponse, 0, len(users))
+	for i := range users {
+		data = append(data, *toUserResponse(&users[i]))
+	}
+
+	return &dto.UserListResponse{
+		Users:      data,
+		Pagination: pagination,
+	}, nil
+}
+
+func encodeUserCursor(user *models.User, sortBy string) string {
+	cursor := dto.UserCursor{ID: user.ID}
+	switch sortBy {
+	case "name":
+		cursor.Value = user.Name
+	case "username":
+		cursor.Value = user.UserName
+	case "email":
+		cursor.Value = user.Email
+	case "createdAt":
+		if user.CreatedAt != nil {
+			cursor.Value = user.CreatedAt.Format(time.RFC3339Nano)
+		}
+	}
+
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeUserCursor(value string) (*dto.UserCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, errConstant.ErrInvalidCursor
+	}
+
+	cursor := &dto.UserCursor{}
+	err = json.Unmarshal(raw, cursor)
+	if err != nil || cursor.ID == 0 {
+		return nil, errConstant.ErrInvalidCursor
+	}
+	return cursor, nil
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+	errConstant "user-service/constants/error"
+	"user-service/domain/models"
+)
+
+func TestUserCursorRoundTrip(t *testing.T) {
+	createdAt := time.Date(2024, 5, 17, 8, 30, 15, 123456789, time.UTC)
+	user := &models.User{
+		ID:        42,
+		Name:      "Jane Doe",
+		UserName:  "jane",
+		Email:     "jane@example.com",
+		CreatedAt: &createdAt,
+	}
+
+	tests := []struct {
+		sortBy string
+		value  string
+	}{
+		{sortBy: "name", value: "Jane Doe"},
+		{sortBy: "username", value: "jane"},
+		{sortBy: "email", value: "jane@example.com"},
+		{sortBy: "createdAt", value: "2024-05-17T08:30:15.123456789Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sortBy, func(t *testing.T) {
+			cursor, err := decodeUserCursor(encodeUserCursor(user, tt.sortBy))
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if cursor.ID != user.ID || cursor.Value != tt.value {
+				t.Errorf("cursor = %+v, want {Value:%s ID:%d}", *cursor, tt.value, user.ID)
+			}
+		})
+	}
+}
+
+func TestDecodeUserCursorRejectsMalformed(t *testing.T) {
+	valid := encodeUserCursor(&models.User{ID: 42, Name: "Jane"}, "name")
+
+	tests := map[string]string{
+		"not base64":        "%%%",
+		"standard base64":   base64.StdEncoding.EncodeToString([]byte(`{"v":"Jane","id":42}`)) + "=",
+		"not json":          base64.RawURLEncoding.EncodeToString([]byte("v=Jane&id=42")),
+		"missing id":        base64.RawURLEncoding.EncodeToString([]byte(`{"v":"Jane"}`)),
+		"zero id":           base64.RawURLEncoding.EncodeToString([]byte(`{"v":"Jane","id":0}`)),
+		"negative id":       base64.RawURLEncoding.EncodeToString([]byte(`{"v":"Jane","id":-1}`)),
+		"id of wrong type":  base64.RawURLEncoding.EncodeToString([]byte(`{"v":"Jane","id":"42"}`)),
+		"truncated":         valid[:len(valid)-4],
+		"trailing garbage":  valid + "!",
+		"empty json object": base64.RawURLEncoding.EncodeToString([]byte(`{}`)),
+	}
+
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := decodeUserCursor(value)
+			if !errors.Is(err, errConstant.ErrInvalidCursor) {
+				t.Errorf("decodeUserCursor(%q) error = %v, want %v", value, err, errConstant.ErrInvalidCursor)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"user-service/common/jwk"
+	"user-service/common/totp"
+	"user-service/config"
+	"user-service/constants"
+	errConstant "user-service/constants/error"
+	"user-service/domain/dto"
+	"user-service/domain/models"
+	"user-service/repositories"
+	auditRepositories "user-service/repositories/audit"
+	loginAttemptRepositories "user-service/repositories/loginattempt"
+	mfaRepositories "user-service/repositories/mfa"
+	userRepositories "user-service/repositories/user"
+	revocationServices "user-service/services/revocation"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const loginPassword = "correct-password"
+
+type loginUserRepository struct {
+	userRepositories.IUserRepository
+	user *models.User
+}
+
+func (r *loginUserRepository) FindByUsername(_ context.Context, username string) (*models.User, error) {
+	if username != r.user.UserName {
+		return nil, errConstant.ErrUserNotFound
+	}
+	return r.user, nil
+}
+
+func (r *loginUserRepository) FindByUUID(_ context.Context, uuid string) (*models.User, error) {
+	if uuid != r.user.UUID.String() {
+		return nil, errConstant.ErrUserNotFound
+	}
+	return r.user, nil
+}
+
+// loginAttemptRepository counts failures in memory; the window is ignored
+// since the test runs well inside it.
+type loginAttemptRepository struct {
+	loginAttemptRepositories.ILoginAttemptRepository
+	attempts map[string]*models.LoginAttempt
+}
+
+func (r *loginAttemptRepository) FindByKeys(_ context.Context, keys []string) ([]models.LoginAttempt, error) {
+	result := []models.LoginAttempt{}
+	for _, key := range keys {
+		if attempt, ok := r.attempts[key]; ok {
+			result = append(result, *attempt)
+		}
+	}
+	return result, nil
+}
+
+func (r *loginAttemptRepository) RecordFailure(_ context.Context, key string, _ time.Time) (*models.LoginAttempt, error) {
+	attempt, ok := r.attempts[key]
+	if !ok {
+		attempt = &models.LoginAttempt{Key: key}
+		r.attempts[key] = attempt
+	}
+	attempt.FailedCount++
+	return attempt, nil
+}
+
+func (r *loginAttemptRepository) Lock(_ context.Context, key string, until time.Time) error {
+	r.attempts[key].LockedUntil = &until
+	return nil
+}
+
+func (r *loginAttemptRepository) Reset(_ context.Context, keys []string) error {
+	for _, key := range keys {
+		delete(r.attempts, key)
+	}
+	return nil
+}
+
+type enabledMFARepository struct {
+	mfaRepository
+	mfa *models.UserMFA
+}
+
+func (r *enabledMFARepository) FindByUserID(context.Context, uint) (*models.UserMFA, error) {
+	return r.mfa, nil
+}
+
+type auditRepository struct {
+	auditRepositories.IAuditRepository
+}
+
+func (r *auditRepository) Create(context.Context, *models.AuditLog) error {
+	return nil
+}
+
+type loginRepositoryRegistry struct {
+	repositories.IRepositoryRegistry
+	user         *loginUserRepository
+	loginAttempt *loginAttemptRepository
+	mfa          *enabledMFARepository
+}
+
+func (r *loginRepositoryRegistry) GetUser() userRepositories.IUserRepository {
+	return r.user
+}
+
+func (r *loginRepositoryRegistry) GetLoginAttempt() loginAttemptRepositories.ILoginAttemptRepository {
+	return r.loginAttempt
+}
+
+func (r *loginRepositoryRegistry) GetMFA() mfaRepositories.IMFARepository {
+	return r.mfa
+}
+
+func (r *loginRepositoryRegistry) GetAudit() auditRepositories.IAuditRepository {
+	return &auditRepository{}
+}
+
+type revocationService struct {
+	revocationServices.IRevocationService
+}
+
+func (r *revocationService) IsRevoked(context.Context, string, uuid.UUID, time.Time) (bool, error) {
+	return false, nil
+}
+
+func TestPasswordLoginDoesNotResetFailedSecondFactors(t *testing.T) {
+	_, mfa, secret := newMFAFixture(t)
+	config.Config.JwtSecretKey = "test-jwt-secret"
+	config.Config.LoginThrottle = config.LoginThrottle{MaxAttempts: 10}
+	t.Cleanup(func() { config.Config.LoginThrottle = config.LoginThrottle{} })
+	jwk.Init()
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(loginPassword), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("hash password: %v", err)
+	}
+	user := &models.User{
+		ID:       1,
+		UUID:     uuid.New(),
+		UserName: "jane",
+		Password: string(hashedPassword),
+		Status:   constants.UserStatusActive,
+	}
+	mfa.EnabledAt = &time.Time{}
+	attempts := &loginAttemptRepository{attempts: map[string]*models.LoginAttempt{}}
+	service := &UserService{
+		repository: &loginRepositoryRegistry{
+			user:         &loginUserRepository{user: user},
+			loginAttempt: attempts,
+			mfa:          &enabledMFARepository{mfa: mfa},
+		},
+		revocation: &revocationService{},
+	}
+
+	wrongCode, err := totp.CodeAt(secret, totp.Step(time.Now())+100)
+	if err != nil {
+		t.Fatalf("CodeAt: %v", err)
+	}
+	ctx := context.Background()
+	const failures = 3
+	for round := 0; round < 2; round++ {
+		login, err := service.Login(ctx, &dto.LoginRequest{Username: user.UserName, Password: loginPassword})
+		if err != nil {
+			t.Fatalf("round %d login: %v", round, err)
+		}
+		if !login.MFARequired {
+			t.Fatalf("round %d login did not ask for a second factor", round)
+		}
+
+		for i := 0; i < failures; i++ {
+			_, err = service.LoginMFA(ctx, &dto.LoginMFARequest{MFAToken: login.MFAToken, Code: wrongCode})
+			if !errors.Is(err, errConstant.ErrInvalidMFACode) {
+				t.Fatalf("round %d wrong code error = %v, want %v", round, err, errConstant.ErrInvalidMFACode)
+			}
+		}
+	}
+
+	// The second password login must not have cleared the first round.
+	attempt := attempts.attempts[accountThrottleKey(user.UserName)]
+	if attempt == nil || attempt.FailedCount != 2*failures {
+		t.Fatalf("account failures = %v, want %d", attempt, 2*failures)
+	}
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"errors"
+	"user-service/common/metrics"
+	errConstant "user-service/constants/error"
+	"user-service/domain/dto"
+)
+
+func recordLogin(response *dto.LoginResponse, err error) {
+	var outcome string
+	switch {
+	case err == nil && response != nil && response.MFARequired:
+		outcome = metrics.LoginMFARequired
+	case err == nil:
+		outcome = metrics.LoginSuccess
+	case errors.Is(err, errConstant.ErrInvalidCredentials):
+		outcome = metrics.LoginInvalidCredentials
+	case errors.Is(err, errConstant.ErrInvalidMFACode), errors.Is(err, errConstant.ErrInvalidMFAToken):
+		outcome = metrics.LoginInvalidMFA
+	case errors.Is(err, errConstant.ErrLoginLocked):
+		outcome = metrics.LoginLocked
+	case errors.Is(err, errConstant.ErrUserInactive), errors.Is(err, errConstant.ErrEmailNotVerified):
+		outcome = metrics.LoginDenied
+	default:
+		outcome = metrics.LoginError
+	}
+	metrics.Logins.WithLabelValues(outcome).Inc()
+}
+
+func recordRegistration(err error) {
+	var outcome string
+	switch {
+	case err == nil:
+		outcome = metrics.RegistrationSuccess
+	case errors.Is(err, errConstant.ErrUsernameExist), errors.Is(err, errConstant.ErrEmailExist):
+		outcome = metrics.RegistrationExists
+	case errors.Is(err, errConstant.ErrPasswordDoesNotMatch):
+		outcome = metrics.RegistrationInvalid
+	default:
+		outcome = metrics.RegistrationError
+	}
+	metrics.Registrations.WithLabelValues(outcome).Inc()
+}
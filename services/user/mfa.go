@@ -0,0 +1,319 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+	"user-service/common/jwk"
+	"user-service/common/logger"
+	"user-service/common/secretbox"
+	"user-service/common/totp"
+	"user-service/config"
+	"user-service/constants"
+	errConstant "user-service/constants/error"
+	"user-service/domain/dto"
+	"user-service/domain/models"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	mfaTokenPurpose           = "mfa"
+	defaultMFATokenExpiration = 5
+	totpSkew                  = 1
+	recoveryCodeCount         = 10
+)
+
+// MFAClaims is the short-lived challenge token returned by Login when the
+// user has two-factor authentication enabled. It carries no user data and is
+// rejected by Authenticate.
+type MFAClaims struct {
+	Purpose string `json:"purpose"`
+	jwt.RegisteredClaims
+}
+
+func generateMFAToken(ctx context.Context, user *models.User) (string, error) {
+	expiration := config.Config.MFATokenExpiration
+	if expiration <= 0 {
+		expiration = defaultMFATokenExpiration
+	}
+
+	now := time.Now()
+	return signToken(ctx, &MFAClaims{
+		Purpose: mfaTokenPurpose,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Subject:   user.UUID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Duration(expiration) * time.Minute)),
+		},
+	})
+}
+
+func parseMFAToken(token string) (*MFAClaims, error) {
+	claims := &MFAClaims{}
+	parsed, err := jwt.ParseWithClaims(token, claims, jwk.Keyfunc, jwt.WithValidMethods(jwk.ValidMethods()))
+	if err != nil || !parsed.Valid || claims.Purpose != mfaTokenPurpose || claims.ID == "" || claims.IssuedAt == nil {
+		return nil, errConstant.ErrInvalidMFAToken
+	}
+	return claims, nil
+}
+
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return hashToken(normalized)
+}
+
+func generateRecoveryCodes() ([]string, []string, error) {
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		raw := make([]byte, 7)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, err
+		}
+		value := encoding.EncodeToString(raw)[:10]
+		code := value[:5] + "-" + value[5:]
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+func (u *UserService) currentUser(ctx context.Context) (*models.User, error) {
+	userLogin, ok := ctx.Value(constants.UserLogin).(*dto.UserResponse)
+	if !ok || userLogin == nil {
+		return nil, errConstant.ErrUnauthorized
+	}
+	return u.repository.GetUser().FindByUUID(ctx, userLogin.UUID.String())
+}
+
+// verifySecondFactor accepts either a TOTP code, which cannot be replayed
+// within its time window, or an unused recovery code.
+func (u *UserService) verifySecondFactor(ctx context.Context, mfa *models.UserMFA, code, recoveryCode string) error {
+	if code != "" {
+		secret, err := secretbox.Open(config.Config.EncryptionKey, mfa.Secret)
+		if err != nil {
+			return err
+		}
+
+		step, ok := totp.Validate(secret, code, time.Now(), totpSkew)
+		if !ok {
+			return errConstant.ErrInvalidMFACode
+		}
+
+		fresh, err := u.repository.GetMFA().UseStep(ctx, mfa.UserID, step)
+		if err != nil {
+			return err
+		}
+		if !fresh {
+			return errConstant.ErrInvalidMFACode
+		}
+		return nil
+	}
+
+	used, err := u.repository.GetMFA().UseRecoveryCode(ctx, mfa.UserID, hashRecoveryCode(recoveryCode))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return errConstant.ErrInvalidMFACode
+	}
+	return nil
+}
+
+func (u *UserService) recordMFAAudit(ctx context.Context, user *models.User, action string) {
+	err := u.repository.GetAudit().Create(ctx, &models.AuditLog{
+		ActorUUID:  &user.UUID,
+		Action:     action,
+		TargetUUID: &user.UUID,
+		Outcome:    constants.AuditOutcomeSuccess,
+	})
+	if err != nil {
+		logger.FromContext(ctx).Errorf("failed to record %s of user %s: %v", action, user.UUID, err)
+	}
+}
+
+// LoginMFA exchanges the challenge token from Login and a second factor for
+// the access and refresh tokens. Each challenge token can be used once.
+func (u *UserService) LoginMFA(ctx context.Context, req *dto.LoginMFARequest) (response *dto.LoginResponse, err error) {
+	defer func() { recordLogin(response, err) }()
+
+	claims, err := parseMFAToken(req.MFAToken)
+	if err != nil {
+		return nil, err
+	}
+
+	userUUID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil, errConstant.ErrInvalidMFAToken
+	}
+
+	revoked, err := u.revocation.IsRevoked(ctx, claims.ID, userUUID, claims.IssuedAt.Time)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, errConstant.ErrInvalidMFAToken
+	}
+
+	user, err := u.repository.GetUser().FindByUUID(ctx, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	err = checkUserActive(user)
+	if err != nil {
+		return nil, err
+	}
+
+	mfa, err := u.repository.GetMFA().FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa == nil || mfa.EnabledAt == nil {
+		return nil, errConstant.ErrMFANotEnabled
+	}
+
+	throttleKeys := loginThrottleKeys(user.UserName, req.IPAddress)
+	err = u.checkLoginAllowed(ctx, throttleKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.verifySecondFactor(ctx, mfa, req.Code, req.RecoveryCode)
+	if err != nil {
+		if errors.Is(err, errConstant.ErrInvalidMFACode) {
+			u.recordLoginFailure(ctx, throttleKeys)
+			u.recordLoginAudit(ctx, user, constants.AuditOutcomeFailure, "invalid second factor", req.IPAddress)
+		}
+		return nil, err
+	}
+
+	err = u.revocation.RevokeToken(ctx, claims.ID, userUUID, claims.ExpiresAt.Time)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.completeLogin(ctx, user, req.IPAddress)
+}
+
+// EnrollMFA starts (or restarts) enrollment with a new secret. The factor is
+// not enforced until ConfirmMFA succeeds.
+func (u *UserService) EnrollMFA(ctx context.Context) (*dto.MFAEnrollResponse, error) {
+	user, err := u.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mfa, err := u.repository.GetMFA().FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa != nil && mfa.EnabledAt != nil {
+		return nil, errConstant.ErrMFAAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := secretbox.Seal(config.Config.EncryptionKey, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.repository.GetMFA().Upsert(ctx, &models.UserMFA{
+		UserID: user.ID,
+		Secret: sealed,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.MFAEnrollResponse{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(config.Config.AppName, user.UserName, secret),
+	}, nil
+}
+
+// ConfirmMFA enables two-factor authentication once the user proves their
+// authenticator works, and returns recovery codes that are shown only once.
+func (u *UserService) ConfirmMFA(ctx context.Context, req *dto.MFACodeRequest) (*dto.MFARecoveryCodesResponse, error) {
+	user, err := u.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mfa, err := u.repository.GetMFA().FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa == nil {
+		return nil, errConstant.ErrMFANotEnrolled
+	}
+	if mfa.EnabledAt != nil {
+		return nil, errConstant.ErrMFAAlreadyEnabled
+	}
+
+	secret, err := secretbox.Open(config.Config.EncryptionKey, mfa.Secret)
+	if err != nil {
+		return nil, err
+	}
+
+	step, ok := totp.Validate(secret, req.Code, time.Now(), totpSkew)
+	if !ok {
+		return nil, errConstant.ErrInvalidMFACode
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.repository.GetMFA().Enable(ctx, user.ID, step, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	u.recordMFAAudit(ctx, user, constants.AuditActionMFAEnabled)
+	return &dto.MFARecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+func (u *UserService) DisableMFA(ctx context.Context, req *dto.DisableMFARequest) error {
+	user, err := u.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = comparePassword(ctx, []byte(user.Password), req.Password)
+	if err != nil {
+		return errConstant.ErrPasswordIncorrect
+	}
+
+	mfa, err := u.repository.GetMFA().FindByUserID(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if mfa == nil || mfa.EnabledAt == nil {
+		return errConstant.ErrMFANotEnabled
+	}
+
+	err = u.verifySecondFactor(ctx, mfa, req.Code, req.RecoveryCode)
+	if err != nil {
+		return err
+	}
+
+	err = u.repository.GetMFA().Delete(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	u.recordMFAAudit(ctx, user, constants.AuditActionMFADisabled)
+	return nil
+}
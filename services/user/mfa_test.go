@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"user-service/common/secretbox"
+	"user-service/common/totp"
+	"user-service/config"
+	errConstant "user-service/constants/error"
+	"user-service/domain/models"
+	"user-service/repositories"
+	mfaRepositories "user-service/repositories/mfa"
+)
+
+// mfaRepository keeps the last used step in memory with the same rule as the
+// SQL UseStep: only a later step is accepted.
+type mfaRepository struct {
+	mfaRepositories.IMFARepository
+	lastUsedStep int64
+}
+
+func (r *mfaRepository) UseStep(_ context.Context, _ uint, step int64) (bool, error) {
+	if step <= r.lastUsedStep {
+		return false, nil
+	}
+	r.lastUsedStep = step
+	return true, nil
+}
+
+type repositoryRegistry struct {
+	repositories.IRepositoryRegistry
+	mfa *mfaRepository
+}
+
+func (r *repositoryRegistry) GetMFA() mfaRepositories.IMFARepository {
+	return r.mfa
+}
+
+func newMFAFixture(t *testing.T) (*UserService, *models.UserMFA, string) {
+	t.Helper()
+	config.Config.EncryptionKey = "test-encryption-key"
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatalf("generate secret: %v", err)
+	}
+	sealed, err := secretbox.Seal(config.Config.EncryptionKey, secret)
+	if err != nil {
+		t.Fatalf("seal secret: %v", err)
+	}
+
+	service := &UserService{repository: &repositoryRegistry{mfa: &mfaRepository{}}}
+	return service, &models.UserMFA{UserID: 1, Secret: sealed}, secret
+}
+
+func TestVerifySecondFactorRejectsReusedCode(t *testing.T) {
+	service, mfa, secret := newMFAFixture(t)
+	code, err := totp.CodeAt(secret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatalf("CodeAt: %v", err)
+	}
+
+	err = service.verifySecondFactor(context.Background(), mfa, code, "")
+	if err != nil {
+		t.Fatalf("first use: %v", err)
+	}
+	err = service.verifySecondFactor(context.Background(), mfa, code, "")
+	if !errors.Is(err, errConstant.ErrInvalidMFACode) {
+		t.Fatalf("second use error = %v, want %v", err, errConstant.ErrInvalidMFACode)
+	}
+}
+
+func TestVerifySecondFactorRejectsOlderStepAfterNewer(t *testing.T) {
+	service, mfa, secret := newMFAFixture(t)
+	current := totp.Step(time.Now())
+	previous, err := totp.CodeAt(secret, current-1)
+	if err != nil {
+		t.Fatalf("CodeAt: %v", err)
+	}
+	code, err := totp.CodeAt(secret, current)
+	if err != nil {
+		t.Fatalf("CodeAt: %v", err)
+	}
+
+	err = service.verifySecondFactor(context.Background(), mfa, code, "")
+	if err != nil {
+		t.Fatalf("current code: %v", err)
+	}
+	// The previous step is inside the skew window but older than the one used.
+	err = service.verifySecondFactor(context.Background(), mfa, previous, "")
+	if !errors.Is(err, errConstant.ErrInvalidMFACode) {
+		t.Fatalf("previous code error = %v, want %v", err, errConstant.ErrInvalidMFACode)
+	}
+}
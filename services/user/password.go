@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"user-service/clients/notifier"
+	"user-service/common/logger"
+	"user-service/common/metrics"
+	"user-service/config"
+	"user-service/constants"
+	errConstant "user-service/constants/error"
+	"user-service/domain/dto"
+	"user-service/domain/models"
+)
+
+const (
+	defaultPasswordResetExpiration = 30
+	notifyTimeout                  = 30 * time.Second
+)
+
+// issueUserToken stores a new single-use token for the user, invalidating any
+// outstanding token with the same purpose, and returns the plaintext value.
+func (u *UserService) issueUserToken(ctx context.Context, userID uint, purpose string, ttl time.Duration) (string, error) {
+	err := u.repository.GetUserToken().InvalidateByUserID(ctx, userID, purpose)
+	if err != nil {
+		return "", err
+	}
+
+	plain, err := generateOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+
+	_, err = u.repository.GetUserToken().Create(ctx, &models.UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: hashToken(plain),
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	if err != nil {
+		return "", err
+	}
+	return plain, nil
+}
+
+// consumeUserToken redeems a single-use token and returns its owner's token row.
+func (u *UserService) consumeUserToken(ctx context.Context, purpose, plain string) (*models.UserToken, error) {
+	token, err := u.repository.GetUserToken().FindValid(ctx, purpose, hashToken(plain))
+	if err != nil {
+		return nil, err
+	}
+
+	used, err := u.repository.GetUserToken().MarkUsed(ctx, token.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, errConstant.ErrInvalidUserToken
+	}
+	return token, nil
+}
+
+// sendAsync runs send in the background so the response time of the request
+// does not depend on the mail server or on whether anything was sent. The
+// request ID and logger of the request are kept, its cancellation is not, and
+// send is given notifyTimeout to finish.
+func (u *UserService) sendAsync(ctx context.Context, what string, send func(context.Context) error) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), notifyTimeout)
+		defer cancel()
+
+		err := send(ctx)
+		if err != nil {
+			logger.FromContext(ctx).Errorf("failed to send %s: %v", what, err)
+		}
+	}()
+}
+
+func buildLink(template, token string) string {
+	if strings.Contains(template, "%s") {
+		return fmt.Sprintf(template, token)
+	}
+	return token
+}
+
+// ForgotPassword sends a reset link when the email belongs to an account.
+// Requests are throttled per email and per IP like logins, and counted
+// whether or not the account exists. The account is looked up and the link
+// sent in the background, so neither the result nor the response time tells
+// whether the email is registered.
+func (u *UserService) ForgotPassword(ctx context.Context, req *dto.ForgotPasswordRequest) error {
+	throttleKeys := passwordResetThrottleKeys(req.Email, req.IPAddress)
+	throttled, err := u.isThrottled(ctx, throttleKeys)
+	if err != nil {
+		return err
+	}
+	if throttled {
+		metrics.RateLimitRejections.WithLabelValues(metrics.LimiterPasswordReset).Inc()
+		return errConstant.ErrTooManyRequests
+	}
+	u.recordThrottledAttempt(ctx, config.Config.PasswordResetThrottle, throttleKeys)
+
+	email := req.Email
+	u.sendAsync(ctx, "password reset", func(ctx context.Context) error {
+		return u.sendPasswordReset(ctx, email)
+	})
+	return nil
+}
+
+func (u *UserService) sendPasswordReset(ctx context.Context, email string) error {
+	user, err := u.repository.GetUser().FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, errConstant.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	expiration := config.Config.PasswordResetExpiration
+	if expiration <= 0 {
+		expiration = defaultPasswordResetExpiration
+	}
+
+	token, err := u.issueUserToken(ctx, user.ID, constants.UserTokenPurposePasswordReset, time.Duration(expiration)*time.Minute)
+	if err != nil {
+		return err
+	}
+
+	return u.notifier.Send(ctx, &notifier.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nUse the link below to reset your password. It expires in %d minutes.\n\n%s\n\nIf you did not ask for a password reset, you can ignore this email.",
+			user.Name, expiration, buildLink(config.Config.PasswordResetURL, token),
+		),
+	})
+}
+
+// ResetPassword sets a new password using a reset token and signs the user out
+// of every existing session.
+func (u *UserService) ResetPassword(ctx context.Context, req *dto.ResetPasswordRequest) error {
+	if req.Password != req.ConfirmPassword {
+		return errConstant.ErrPasswordDoesNotMatch
+	}
+
+	token, err := u.consumeUserToken(ctx, constants.UserTokenPurposePasswordReset, req.Token)
+	if err != nil {
+		return err
+	}
+
+	user, err := u.repository.GetUser().FindByIDWithRole(ctx, token.UserID)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := hashPassword(ctx, req.Password)
+	if err != nil {
+		return err
+	}
+
+	err = u.repository.GetUser().UpdatePassword(ctx, user.ID, string(hashedPassword), false)
+	if err != nil {
+		return err
+	}
+
+	err = u.revokeAllSessions(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	err = u.repository.GetAudit().Create(ctx, &models.AuditLog{
+		ActorUUID:  &user.UUID,
+		Action:     constants.AuditActionPasswordReset,
+		TargetUUID: &user.UUID,
+		Outcome:    constants.AuditOutcomeSuccess,
+	})
+	if err != nil {
+		logger.FromContext(ctx).Errorf("failed to record password reset of user %s: %v", user.UUID, err)
+	}
+	return nil
+}
+
+// ChangePassword replaces the logged-in user's password after checking the
+// current one. It also clears a pending forced change, signs the user out of
+// every other session and returns a fresh pair of tokens.
+func (u *UserService) ChangePassword(ctx context.Context, req *dto.ChangePasswordRequest) (*dto.LoginResponse, error) {
+	user, err := u.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = comparePassword(ctx, []byte(user.Password), req.CurrentPassword)
+	if err != nil {
+		return nil, errConstant.ErrPasswordIncorrect
+	}
+
+	if req.Password != req.ConfirmPassword {
+		return nil, errConstant.ErrPasswordDoesNotMatch
+	}
+	if req.Password == req.CurrentPassword {
+		return nil, errConstant.ErrPasswordUnchanged
+	}
+
+	hashedPassword, err := hashPassword(ctx, req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.repository.GetUser().UpdatePassword(ctx, user.ID, string(hashedPassword), false)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.revokeAllSessions(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.repository.GetAudit().Create(ctx, &models.AuditLog{
+		ActorUUID:  &user.UUID,
+		Action:     constants.AuditActionPasswordChange,
+		TargetUUID: &user.UUID,
+		Outcome:    constants.AuditOutcomeSuccess,
+		IPAddress:  req.IPAddress,
+	})
+	if err != nil {
+		logger.FromContext(ctx).Errorf("failed to record password change of user %s: %v", user.UUID, err)
+	}
+
+	user.Password = string(hashedPassword)
+	user.MustChangePassword = false
+	return u.completeLogin(ctx, user, req.IPAddress)
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"user-service/common/logger"
+	"user-service/constants"
+	errConstant "user-service/constants/error"
+	"user-service/domain/dto"
+	"user-service/domain/models"
+)
+
+func (u *UserService) recordLoginAudit(ctx context.Context, user *models.User, outcome, reason, ipAddress string) {
+	err := u.repository.GetAudit().Create(ctx, &models.AuditLog{
+		ActorUUID:  &user.UUID,
+		Action:     constants.AuditActionLogin,
+		TargetUUID: &user.UUID,
+		Outcome:    outcome,
+		Reason:     reason,
+		IPAddress:  ipAddress,
+	})
+	if err != nil {
+		logger.FromContext(ctx).Errorf("failed to record login of user %s: %v", user.UUID, err)
+	}
+}
+
+func toExportAudit(log models.AuditLog) dto.UserExportAudit {
+	return dto.UserExportAudit{
+		Action:     log.Action,
+		Outcome:    log.Outcome,
+		ActorUUID:  log.ActorUUID,
+		TargetUUID: log.TargetUUID,
+		Reason:     log.Reason,
+		IPAddress:  log.IPAddress,
+		CreatedAt:  log.CreatedAt,
+	}
+}
+
+// ExportUserData collects everything stored about the current user. Secrets
+// such as password and token hashes are left out.
+func (u *UserService) ExportUserData(ctx context.Context) (*dto.UserDataExport, error) {
+	user, err := u.currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mfa, err := u.repository.GetMFA().FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := u.repository.GetToken().FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := u.repository.GetAudit().FindByUserUUID(ctx, user.UUID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &dto.UserDataExport{
+		ExportedAt: time.Now(),
+		Profile: dto.UserExportProfile{
+			UUID:            user.UUID,
+			Name:            user.Name,
+			UserName:        user.UserName,
+			Email:           user.Email,
+			PhoneNumber:     user.PhoneNumber,
+			Status:          user.Status,
+			EmailVerifiedAt: user.EmailVerifiedAt,
+			CreatedAt:       user.CreatedAt,
+			UpdatedAt:       user.UpdatedAt,
+		},
+		Role: dto.UserExportRole{
+			Code: user.Role.Code,
+			Name: user.Role.Name,
+		},
+		Sessions:     make([]dto.UserExportSession, 0, len(tokens)),
+		LoginHistory: []dto.UserExportAudit{},
+		AuditEntries: []dto.UserExportAudit{},
+	}
+	if mfa != nil && mfa.EnabledAt != nil {
+		export.MFA = dto.UserExportMFA{Enabled: true, EnabledAt: mfa.EnabledAt}
+	}
+	for _, token := range tokens {
+		export.Sessions = append(export.Sessions, dto.UserExportSession{
+			CreatedAt: token.CreatedAt,
+			ExpiresAt: token.ExpiresAt,
+			RevokedAt: token.RevokedAt,
+		})
+	}
+	for _, log := range logs {
+		if log.Action == constants.AuditActionLogin {
+			export.LoginHistory = append(export.LoginHistory, toExportAudit(log))
+			continue
+		}
+		export.AuditEntries = append(export.AuditEntries, toExportAudit(log))
+	}
+
+	return export, nil
+}
+
+// EraseUser anonymizes a user's personal data across every table and keeps a
+// tombstone with the UUID. The affected row counts are written to the audit
+// log as proof of erasure in the same transaction, so the erasure is rolled
+// back if that entry cannot be stored.
+func (u *UserService) EraseUser(ctx context.Context, uuid string) error {
+	actor, err := u.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	target, err := u.repository.GetUser().FindByUUIDWithDeleted(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if target.ErasedAt != nil {
+		return errConstant.ErrUserNotFound
+	}
+	if target.ID == actor.ID {
+		return errConstant.ErrForbidden
+	}
+
+	err = u.revokeAllSessions(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	err = u.resetLoginFailures(ctx, target.UserName)
+	if err != nil {
+		return err
+	}
+
+	_, err = u.repository.GetUser().Erase(ctx, target, func(affected map[string]int64) *models.AuditLog {
+		return &models.AuditLog{
+			ActorUUID:  &actor.UUID,
+			Action:     constants.AuditActionUserErase,
+			TargetUUID: &target.UUID,
+			Outcome:    constants.AuditOutcomeSuccess,
+			Reason:     "erased " + formatRowCounts(affected),
+		}
+	})
+	return err
+}
+
+// formatRowCounts renders the counts as "table=n" pairs sorted by table.
+func formatRowCounts(affected map[string]int64) string {
+	tables := make([]string, 0, len(affected))
+	for table := range affected {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	counts := make([]string, 0, len(tables))
+	for _, table := range tables {
+		counts = append(counts, fmt.Sprintf("%s=%d", table, affected[table]))
+	}
+	return strings.Join(counts, " ")
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"user-service/common/logger"
+	"user-service/constants"
+	errConstant "user-service/constants/error"
+	"user-service/domain/dto"
+	"user-service/domain/models"
+)
+
+func checkUserActive(user *models.User) error {
+	if user.Status != constants.UserStatusActive {
+		return errConstant.ErrUserInactive
+	}
+	return nil
+}
+
+func (u *UserService) recordStatusAudit(ctx context.Context, actor, target *models.User, action string) {
+	err := u.repository.GetAudit().Create(ctx, &models.AuditLog{
+		ActorUUID:  &actor.UUID,
+		Action:     action,
+		TargetUUID: &target.UUID,
+		Outcome:    constants.AuditOutcomeSuccess,
+	})
+	if err != nil {
+		logger.FromContext(ctx).Errorf("failed to record %s of user %s: %v", action, target.UUID, err)
+	}
+}
+
+// findManagedUser loads the target of an admin action. Admins cannot suspend
+// or delete their own account this way, so they cannot lock themselves out.
+func (u *UserService) findManagedUser(ctx context.Context, uuid string) (*models.User, *models.User, error) {
+	actor, err := u.currentUser(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	target, err := u.repository.GetUser().FindByUUID(ctx, uuid)
+	if err != nil {
+		return nil, nil, err
+	}
+	if target.ID == actor.ID {
+		return nil, nil, errConstant.ErrForbidden
+	}
+	return actor, target, nil
+}
+
+// setUserStatus changes the status of the target user. Leaving the active
+// status revokes every session, so tokens that are still valid stop working.
+func (u *UserService) setUserStatus(ctx context.Context, uuid, status, action string) error {
+	actor, target, err := u.findManagedUser(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	err = u.repository.GetUser().UpdateStatus(ctx, target.ID, status)
+	if err != nil {
+		return err
+	}
+
+	if status != constants.UserStatusActive {
+		err = u.revokeAllSessions(ctx, target)
+		if err != nil {
+			return err
+		}
+	}
+
+	u.recordStatusAudit(ctx, actor, target, action)
+	return nil
+}
+
+func (u *UserService) SuspendUser(ctx context.Context, uuid string) error {
+	return u.setUserStatus(ctx, uuid, constants.UserStatusSuspended, constants.AuditActionUserSuspend)
+}
+
+func (u *UserService) ReactivateUser(ctx context.Context, uuid string) error {
+	return u.setUserStatus(ctx, uuid, constants.UserStatusActive, constants.AuditActionUserReactivate)
+}
+
+// DeleteUser soft deletes the target user and revokes their sessions.
+func (u *UserService) DeleteUser(ctx context.Context, uuid string) error {
+	actor, target, err := u.findManagedUser(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	err = u.revokeAllSessions(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	err = u.repository.GetUser().Delete(ctx, target.ID)
+	if err != nil {
+		return err
+	}
+
+	u.recordStatusAudit(ctx, actor, target, constants.AuditActionUserDelete)
+	return nil
+}
+
+// CloseAccount deactivates the current user after confirming their password.
+// An admin can reactivate the account later.
+func (u *UserService) CloseAccount(ctx context.Context, req *dto.CloseAccountRequest) error {
+	user, err := u.currentUser(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = comparePassword(ctx, []byte(user.Password), req.Password)
+	if err != nil {
+		return errConstant.ErrPasswordIncorrect
+	}
+
+	err = u.repository.GetUser().UpdateStatus(ctx, user.ID, constants.UserStatusDeactivated)
+	if err != nil {
+		return err
+	}
+
+	err = u.revokeAllSessions(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	u.recordStatusAudit(ctx, user, user, constants.AuditActionUserClose)
+	return nil
+}
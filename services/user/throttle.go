@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+	"user-service/common/logger"
+	"user-service/common/metrics"
+	"user-service/config"
+	"user-service/constants"
+	errConstant "user-service/constants/error"
+	"user-service/domain/dto"
+	"user-service/domain/models"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	defaultLoginMaxAttempts              = 5
+	defaultLoginMaxAttemptsPerIP         = 20
+	defaultPasswordResetMaxAttempts      = 3
+	defaultPasswordResetMaxAttemptsPerIP = 10
+	defaultLoginBackoffSeconds           = 1
+	defaultLoginLockoutMinutes           = 15
+	defaultLoginWindowMinutes            = 15
+)
+
+var (
+	dummyPasswordHash     []byte
+	dummyPasswordHashOnce sync.Once
+)
+
+// compareDummyPassword spends the same time as a real password check so that
+// unknown usernames cannot be told apart by response time.
+func compareDummyPassword(ctx context.Context, password string) {
+	dummyPasswordHashOnce.Do(func() {
+		dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+	})
+	_ = comparePassword(ctx, dummyPasswordHash, password)
+}
+
+func orDefault(value, fallback int) int {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func accountThrottleKey(identifier string) string {
+	return fmt.Sprintf("account:%s", strings.ToLower(strings.TrimSpace(identifier)))
+}
+
+func ipThrottleKey(ip string) string {
+	return fmt.Sprintf("ip:%s", ip)
+}
+
+type throttleKey struct {
+	key         string
+	maxAttempts int
+}
+
+func loginThrottleKeys(identifier, ip string) []throttleKey {
+	cfg := config.Config.LoginThrottle
+	keys := []throttleKey{{
+		key:         accountThrottleKey(identifier),
+		maxAttempts: orDefault(cfg.MaxAttempts, defaultLoginMaxAttempts),
+	}}
+	if ip != "" {
+		keys = append(keys, throttleKey{
+			key:         ipThrottleKey(ip),
+			maxAttempts: orDefault(cfg.MaxAttemptsPerIP, defaultLoginMaxAttemptsPerIP),
+		})
+	}
+	return keys
+}
+
+// passwordResetThrottleKeys keeps reset requests apart from login attempts.
+// The email is hashed so that the key fits the column whatever its length.
+func passwordResetThrottleKeys(email, ip string) []throttleKey {
+	cfg := config.Config.PasswordResetThrottle
+	keys := []throttleKey{{
+		key:         "password_reset:" + accountThrottleKey(hashToken(strings.ToLower(strings.TrimSpace(email)))),
+		maxAttempts: orDefault(cfg.MaxAttempts, defaultPasswordResetMaxAttempts),
+	}}
+	if ip != "" {
+		keys = append(keys, throttleKey{
+			key:         "password_reset:" + ipThrottleKey(ip),
+			maxAttempts: orDefault(cfg.MaxAttemptsPerIP, defaultPasswordResetMaxAttemptsPerIP),
+		})
+	}
+	return keys
+}
+
+// checkLoginAllowed fails while any of the keys is locked or backing off.
+func (u *UserService) checkLoginAllowed(ctx context.Context, keys []throttleKey) error {
+	locked, err := u.isThrottled(ctx, keys)
+	if err != nil {
+		return err
+	}
+	if locked {
+		metrics.RateLimitRejections.WithLabelValues(metrics.LimiterLogin).Inc()
+		return errConstant.ErrLoginLocked
+	}
+	return nil
+}
+
+// recordLoginFailure counts a failed login against every key.
+func (u *UserService) recordLoginFailure(ctx context.Context, keys []throttleKey) {
+	u.recordThrottledAttempt(ctx, config.Config.LoginThrottle, keys)
+}
+
+// isThrottled reports whether any of the keys is locked or backing off.
+func (u *UserService) isThrottled(ctx context.Context, keys []throttleKey) (bool, error) {
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		names = append(names, key.key)
+	}
+
+	attempts, err := u.repository.GetLoginAttempt().FindByKeys(ctx, names)
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	for _, attempt := range attempts {
+		if attempt.LockedUntil != nil && now.Before(*attempt.LockedUntil) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// recordThrottledAttempt counts an attempt against every key. Past half of the
+// allowed attempts each one doubles the wait before the next try, and reaching
+// the limit locks the key for the lockout duration.
+func (u *UserService) recordThrottledAttempt(ctx context.Context, cfg config.LoginThrottle, keys []throttleKey) {
+	window := time.Duration(orDefault(cfg.WindowMinutes, defaultLoginWindowMinutes)) * time.Minute
+	lockout := time.Duration(orDefault(cfg.LockoutMinutes, defaultLoginLockoutMinutes)) * time.Minute
+	backoff := time.Duration(orDefault(cfg.BackoffBaseSeconds, defaultLoginBackoffSeconds)) * time.Second
+
+	for _, key := range keys {
+		attempt, err := u.repository.GetLoginAttempt().RecordFailure(ctx, key.key, time.Now().Add(-window))
+		if err != nil {
+			logger.FromContext(ctx).Errorf("failed to record attempt for %s: %v", key.key, err)
+			continue
+		}
+
+		var wait time.Duration
+		threshold := key.maxAttempts / 2
+		switch {
+		case attempt.FailedCount >= key.maxAttempts:
+			wait = lockout
+		case attempt.FailedCount > threshold:
+			exponent := float64(attempt.FailedCount - threshold - 1)
+			wait = time.Duration(math.Min(float64(backoff)*math.Pow(2, exponent), float64(lockout)))
+		default:
+			continue
+		}
+
+		err = u.repository.GetLoginAttempt().Lock(ctx, key.key, time.Now().Add(wait))
+		if err != nil {
+			logger.FromContext(ctx).Errorf("failed to lock throttle key %s: %v", key.key, err)
+		}
+	}
+}
+
+func (u *UserService) resetLoginFailures(ctx context.Context, identifiers ...string) error {
+	keys := make([]string, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		keys = append(keys, accountThrottleKey(identifier))
+	}
+	return u.repository.GetLoginAttempt().Reset(ctx, keys)
+}
+
+// UnlockUser clears the failed login counters of a user so they can log in
+// again before their lockout expires.
+func (u *UserService) UnlockUser(ctx context.Context, uuid string) error {
+	user, err := u.repository.GetUser().FindByUUID(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	err = u.resetLoginFailures(ctx, user.UserName)
+	if err != nil {
+		return err
+	}
+
+	audit := &models.AuditLog{
+		Action:     constants.AuditActionUserUnlock,
+		TargetUUID: &user.UUID,
+		Outcome:    constants.AuditOutcomeSuccess,
+	}
+	if userLogin, ok := ctx.Value(constants.UserLogin).(*dto.UserResponse); ok && userLogin != nil {
+		audit.ActorUUID = &userLogin.UUID
+	}
+	err = u.repository.GetAudit().Create(ctx, audit)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("failed to record unlock of user %s: %v", user.UUID, err)
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+	"user-service/common/jwk"
+	"user-service/common/tracing"
+	"user-service/config"
+	"user-service/constants"
+	errConstant "user-service/constants/error"
+	"user-service/domain/dto"
+	"user-service/domain/models"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const opaqueTokenBytes = 32
+
+func toUserResponse(user *models.User) *dto.UserResponse {
+	return &dto.UserResponse{
+		UUID:               user.UUID,
+		Name:               user.Name,
+		UserName:           user.UserName,
+		Email:              user.Email,
+		PhoneNumber:        user.PhoneNumber,
+		Role:               strings.ToLower(user.Role.Code),
+		EmailVerified:      user.EmailVerifiedAt != nil,
+		Status:             user.Status,
+		MustChangePassword: user.MustChangePassword,
+	}
+}
+
+func generateAccessToken(ctx context.Context, data *dto.UserResponse) (string, error) {
+	now := time.Now()
+	expirationTime := now.Add(time.Duration(config.Config.JwtExpirationTime) * time.Minute).Unix()
+	claims := &Claims{
+		User: data,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Subject:   data.UUID.String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(time.Unix(expirationTime, 0)),
+		},
+	}
+
+	return signToken(ctx, claims)
+}
+
+// signToken signs the claims inside its own span so that RSA and Ed25519
+// signing shows up separately from the rest of the login.
+func signToken(ctx context.Context, claims jwt.Claims) (token string, err error) {
+	_, span := tracing.Start(ctx, "jwt.Sign")
+	defer func() { tracing.End(span, err) }()
+	return jwk.Sign(claims)
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// generateOpaqueToken returns a random URL-safe token to hand to the client.
+func generateOpaqueToken() (string, error) {
+	raw := make([]byte, opaqueTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// issueRefreshToken stores a new opaque refresh token for the user and returns
+// the plaintext value. Only its SHA-256 hash is persisted.
+func (u *UserService) issueRefreshToken(ctx context.Context, userID uint, familyID uuid.UUID) (string, *models.RefreshToken, error) {
+	plain, err := generateOpaqueToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	token, err := u.repository.GetToken().Create(ctx, &models.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(plain),
+		ExpiresAt: time.Now().Add(time.Duration(config.Config.RefreshTokenExpirationTime) * time.Minute),
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return plain, token, nil
+}
+
+// Refresh rotates a refresh token: the presented token is revoked and a new
+// access/refresh pair from the same family is returned. Presenting a token that
+// was already rotated is treated as theft and revokes the whole family.
+func (u *UserService) Refresh(ctx context.Context, req *dto.RefreshTokenRequest) (*dto.LoginResponse, error) {
+	current, err := u.repository.GetToken().FindByHash(ctx, hashToken(req.RefreshToken))
+	if err != nil {
+		return nil, err
+	}
+
+	if current.RevokedAt != nil {
+		err = u.repository.GetToken().RevokeFamily(ctx, current.FamilyID)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errConstant.ErrRefreshTokenReused
+	}
+
+	if time.Now().After(current.ExpiresAt) {
+		return nil, errConstant.ErrRefreshTokenExpired
+	}
+
+	user, err := u.repository.GetUser().FindByIDWithRole(ctx, current.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if config.Config.RequireEmailVerification && user.EmailVerifiedAt == nil {
+		return nil, errConstant.ErrEmailNotVerified
+	}
+	err = checkUserActive(user)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, next, err := u.issueRefreshToken(ctx, user.ID, current.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+
+	rotated, err := u.repository.GetToken().Revoke(ctx, current.ID, &next.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		// Another request rotated this token first; the family is compromised.
+		err = u.repository.GetToken().RevokeFamily(ctx, current.FamilyID)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errConstant.ErrRefreshTokenReused
+	}
+
+	data := toUserResponse(user)
+	accessToken, err := generateAccessToken(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.LoginResponse{
+		User:         *data,
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// Logout revokes the access token of the current request and, when given, the
+// refresh token family it belongs to.
+func (u *UserService) Logout(ctx context.Context, req *dto.LogoutRequest) error {
+	claims, ok := ctx.Value(constants.Claims).(*Claims)
+	if !ok {
+		return errConstant.ErrUnauthorized
+	}
+
+	err := u.revocation.RevokeToken(ctx, claims.ID, claims.User.UUID, claims.ExpiresAt.Time)
+	if err != nil {
+		return err
+	}
+
+	if req.RefreshToken == "" {
+		return nil
+	}
+
+	current, err := u.repository.GetToken().FindByHash(ctx, hashToken(req.RefreshToken))
+	if err != nil {
+		return err
+	}
+
+	user, err := u.repository.GetUser().FindByUUID(ctx, claims.User.UUID.String())
+	if err != nil {
+		return err
+	}
+	if current.UserID != user.ID {
+		return errConstant.ErrInvalidRefreshToken
+	}
+
+	return u.repository.GetToken().RevokeFamily(ctx, current.FamilyID)
+}
+
+// LogoutAll revokes every access and refresh token of the current user.
+func (u *UserService) LogoutAll(ctx context.Context) error {
+	claims, ok := ctx.Value(constants.Claims).(*Claims)
+	if !ok {
+		return errConstant.ErrUnauthorized
+	}
+
+	user, err := u.repository.GetUser().FindByUUID(ctx, claims.User.UUID.String())
+	if err != nil {
+		return err
+	}
+
+	return u.revokeAllSessions(ctx, user)
+}
+
+func (u *UserService) revokeAllSessions(ctx context.Context, user *models.User) error {
+	err := u.revocation.RevokeAllForUser(ctx, user.UUID)
+	if err != nil {
+		return err
+	}
+
+	return u.repository.GetToken().RevokeAllByUserID(ctx, user.ID)
+}
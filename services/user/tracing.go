@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"user-service/common/tracing"
+	"user-service/domain/dto"
+)
+
+// TracedUserService opens a span around every IUserService call.
+type TracedUserService struct {
+	next IUserService
+}
+
+func NewTracedUserService(next IUserService) IUserService {
+	return &TracedUserService{next: next}
+}
+
+func (s *TracedUserService) Login(ctx context.Context, req *dto.LoginRequest) (result *dto.LoginResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.Login")
+	defer func() { tracing.End(span, err) }()
+	return s.next.Login(ctx, req)
+}
+
+func (s *TracedUserService) Register(ctx context.Context, req *dto.RegisterRequest) (result *dto.RegisterResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.Register")
+	defer func() { tracing.End(span, err) }()
+	return s.next.Register(ctx, req)
+}
+
+func (s *TracedUserService) Update(ctx context.Context, req *dto.UpdateRequest, uuid string) (result *dto.UserResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.Update")
+	defer func() { tracing.End(span, err) }()
+	return s.next.Update(ctx, req, uuid)
+}
+
+func (s *TracedUserService) GetUserLogin(ctx context.Context) (result *dto.UserResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUserLogin")
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetUserLogin(ctx)
+}
+
+func (s *TracedUserService) GetUserByUUID(ctx context.Context, uuid string) (result *dto.UserResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUserByUUID")
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetUserByUUID(ctx, uuid)
+}
+
+func (s *TracedUserService) Refresh(ctx context.Context, req *dto.RefreshTokenRequest) (result *dto.LoginResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.Refresh")
+	defer func() { tracing.End(span, err) }()
+	return s.next.Refresh(ctx, req)
+}
+
+func (s *TracedUserService) Logout(ctx context.Context, req *dto.LogoutRequest) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.Logout")
+	defer func() { tracing.End(span, err) }()
+	return s.next.Logout(ctx, req)
+}
+
+func (s *TracedUserService) LogoutAll(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.LogoutAll")
+	defer func() { tracing.End(span, err) }()
+	return s.next.LogoutAll(ctx)
+}
+
+func (s *TracedUserService) GetUsers(ctx context.Context, req *dto.UserListRequest) (result *dto.UserListResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetUsers")
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetUsers(ctx, req)
+}
+
+func (s *TracedUserService) ForgotPassword(ctx context.Context, req *dto.ForgotPasswordRequest) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.ForgotPassword")
+	defer func() { tracing.End(span, err) }()
+	return s.next.ForgotPassword(ctx, req)
+}
+
+func (s *TracedUserService) ResetPassword(ctx context.Context, req *dto.ResetPasswordRequest) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.ResetPassword")
+	defer func() { tracing.End(span, err) }()
+	return s.next.ResetPassword(ctx, req)
+}
+
+func (s *TracedUserService) ChangePassword(ctx context.Context, req *dto.ChangePasswordRequest) (result *dto.LoginResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.ChangePassword")
+	defer func() { tracing.End(span, err) }()
+	return s.next.ChangePassword(ctx, req)
+}
+
+func (s *TracedUserService) VerifyEmail(ctx context.Context, req *dto.VerifyEmailRequest) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.VerifyEmail")
+	defer func() { tracing.End(span, err) }()
+	return s.next.VerifyEmail(ctx, req)
+}
+
+func (s *TracedUserService) ResendEmailVerification(ctx context.Context, req *dto.ResendEmailVerificationRequest) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.ResendEmailVerification")
+	defer func() { tracing.End(span, err) }()
+	return s.next.ResendEmailVerification(ctx, req)
+}
+
+func (s *TracedUserService) LoginMFA(ctx context.Context, req *dto.LoginMFARequest) (result *dto.LoginResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.LoginMFA")
+	defer func() { tracing.End(span, err) }()
+	return s.next.LoginMFA(ctx, req)
+}
+
+func (s *TracedUserService) EnrollMFA(ctx context.Context) (result *dto.MFAEnrollResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.EnrollMFA")
+	defer func() { tracing.End(span, err) }()
+	return s.next.EnrollMFA(ctx)
+}
+
+func (s *TracedUserService) ConfirmMFA(ctx context.Context, req *dto.MFACodeRequest) (result *dto.MFARecoveryCodesResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.ConfirmMFA")
+	defer func() { tracing.End(span, err) }()
+	return s.next.ConfirmMFA(ctx, req)
+}
+
+func (s *TracedUserService) DisableMFA(ctx context.Context, req *dto.DisableMFARequest) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.DisableMFA")
+	defer func() { tracing.End(span, err) }()
+	return s.next.DisableMFA(ctx, req)
+}
+
+func (s *TracedUserService) UnlockUser(ctx context.Context, uuid string) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.UnlockUser")
+	defer func() { tracing.End(span, err) }()
+	return s.next.UnlockUser(ctx, uuid)
+}
+
+func (s *TracedUserService) SuspendUser(ctx context.Context, uuid string) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.SuspendUser")
+	defer func() { tracing.End(span, err) }()
+	return s.next.SuspendUser(ctx, uuid)
+}
+
+func (s *TracedUserService) ReactivateUser(ctx context.Context, uuid string) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.ReactivateUser")
+	defer func() { tracing.End(span, err) }()
+	return s.next.ReactivateUser(ctx, uuid)
+}
+
+func (s *TracedUserService) DeleteUser(ctx context.Context, uuid string) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.DeleteUser")
+	defer func() { tracing.End(span, err) }()
+	return s.next.DeleteUser(ctx, uuid)
+}
+
+func (s *TracedUserService) CloseAccount(ctx context.Context, req *dto.CloseAccountRequest) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.CloseAccount")
+	defer func() { tracing.End(span, err) }()
+	return s.next.CloseAccount(ctx, req)
+}
+
+func (s *TracedUserService) ExportUserData(ctx context.Context) (result *dto.UserDataExport, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.ExportUserData")
+	defer func() { tracing.End(span, err) }()
+	return s.next.ExportUserData(ctx)
+}
+
+func (s *TracedUserService) EraseUser(ctx context.Context, uuid string) (err error) {
+	ctx, span := tracing.Start(ctx, "UserService.EraseUser")
+	defer func() { tracing.End(span, err) }()
+	return s.next.EraseUser(ctx, uuid)
+}
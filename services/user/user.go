@@ -2,22 +2,27 @@ package services
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"strings"
-	"time"
+	"user-service/clients/notifier"
+	"user-service/common/logger"
+	"user-service/common/util"
 	"user-service/config"
 	"user-service/constants"
 	errConstant "user-service/constants/error"
 	"user-service/domain/dto"
 	"user-service/domain/models"
 	"user-service/repositories"
+	revocationServices "user-service/services/revocation"
 
 	"github.com/golang-jwt/jwt/v5"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/google/uuid"
 )
 
 type UserService struct {
 	repository repositories.IRepositoryRegistry
+	revocation revocationServices.IRevocationService
+	notifier   notifier.INotifier
 }
 
 type IUserService interface {
@@ -27,6 +32,26 @@ type IUserService interface {
 	Update(context.Context, *dto.UpdateRequest, string) (*dto.UserResponse, error)
 	GetUserLogin(context.Context) (*dto.UserResponse, error)
 	GetUserByUUID(context.Context, string) (*dto.UserResponse, error)
+	Refresh(context.Context, *dto.RefreshTokenRequest) (*dto.LoginResponse, error)
+	Logout(context.Context, *dto.LogoutRequest) error
+	LogoutAll(context.Context) error
+	GetUsers(context.Context, *dto.UserListRequest) (*dto.UserListResponse, error)
+	ForgotPassword(context.Context, *dto.ForgotPasswordRequest) error
+	ResetPassword(context.Context, *dto.ResetPasswordRequest) error
+	ChangePassword(context.Context, *dto.ChangePasswordRequest) (*dto.LoginResponse, error)
+	VerifyEmail(context.Context, *dto.VerifyEmailRequest) error
+	ResendEmailVerification(context.Context, *dto.ResendEmailVerificationRequest) error
+	LoginMFA(context.Context, *dto.LoginMFARequest) (*dto.LoginResponse, error)
+	EnrollMFA(context.Context) (*dto.MFAEnrollResponse, error)
+	ConfirmMFA(context.Context, *dto.MFACodeRequest) (*dto.MFARecoveryCodesResponse, error)
+	DisableMFA(context.Context, *dto.DisableMFARequest) error
+	UnlockUser(context.Context, string) error
+	SuspendUser(context.Context, string) error
+	ReactivateUser(context.Context, string) error
+	DeleteUser(context.Context, string) error
+	CloseAccount(context.Context, *dto.CloseAccountRequest) error
+	ExportUserData(context.Context) (*dto.UserDataExport, error)
+	EraseUser(context.Context, string) error
 }
 
 type Claims struct {
@@ -34,62 +59,123 @@ type Claims struct {
 	jwt.RegisteredClaims
 }
 
-func NewUserService(repository repositories.IRepositoryRegistry) IUserService {
-	return &UserService{repository: repository}
+func NewUserService(
+	repository repositories.IRepositoryRegistry,
+	revocation revocationServices.IRevocationService,
+	notifier notifier.INotifier,
+) IUserService {
+	return &UserService{repository: repository, revocation: revocation, notifier: notifier}
 }
 
-func (u *UserService) Login(ctx context.Context, req *dto.LoginRequest) (*dto.LoginResponse, error) {
+func (u *UserService) Login(ctx context.Context, req *dto.LoginRequest) (response *dto.LoginResponse, err error) {
+	defer func() { recordLogin(response, err) }()
 
-	fmt.Println("[DEBUG] Login service dimulai")
-	fmt.Println("[INFO] Mencari user dengan username:", req.Username)
+	identifier := req.Identifier
+	if identifier == "" {
+		identifier = req.Username
+	}
 
-	user, err := u.repository.GetUser().FindByUsername(ctx, req.Username)
+	user, err := u.findByLoginIdentifier(ctx, identifier)
+	if err != nil && !errors.Is(err, errConstant.ErrUserNotFound) {
+		return nil, err
+	}
+
+	// Failures are counted against the account rather than the identifier
+	// so that switching between username and email does not reset them.
+	accountKey := identifier
+	if user != nil {
+		accountKey = user.UserName
+	}
+	throttleKeys := loginThrottleKeys(accountKey, req.IPAddress)
+	err = u.checkLoginAllowed(ctx, throttleKeys)
 	if err != nil {
-		fmt.Println("[ERROR] Gagal menemukan user:", err)
 		return nil, err
 	}
 
-	fmt.Println("[INFO] User ditemukan, cek password")
+	if user == nil {
+		logger.FromContext(ctx).Debug("login with an unknown identifier")
+		compareDummyPassword(ctx, req.Password)
+		u.recordLoginFailure(ctx, throttleKeys)
+		return nil, errConstant.ErrInvalidCredentials
+	}
+
+	err = comparePassword(ctx, []byte(user.Password), req.Password)
+	if err != nil {
+		logger.FromContext(ctx).WithField("user_uuid", user.UUID.String()).Debug("login with an invalid password")
+		u.recordLoginFailure(ctx, throttleKeys)
+		u.recordLoginAudit(ctx, user, constants.AuditOutcomeFailure, "invalid password", req.IPAddress)
+		return nil, errConstant.ErrInvalidCredentials
+	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
+	err = checkUserActive(user)
 	if err != nil {
-		fmt.Println("[ERROR] Password tidak cocok:")
+		u.recordLoginAudit(ctx, user, constants.AuditOutcomeDenied, "account is not active", req.IPAddress)
 		return nil, err
 	}
 
-	fmt.Println("[INFO] Password cocok, buat token JWT")
+	if config.Config.RequireEmailVerification && user.EmailVerifiedAt == nil {
+		u.recordLoginAudit(ctx, user, constants.AuditOutcomeDenied, "email is not verified", req.IPAddress)
+		return nil, errConstant.ErrEmailNotVerified
+	}
 
-	expirationTime := time.Now().Add(time.Duration(config.Config.JwtExpirationTime) * time.Minute).Unix()
-	data := &dto.UserResponse{
-		UUID:        user.UUID,
-		Name:        user.Name,
-		UserName:    user.UserName,
-		Email:       user.Email,
-		PhoneNumber: user.PhoneNumber,
-		Role:        strings.ToLower(user.Role.Code),
+	mfa, err := u.repository.GetMFA().FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa != nil && mfa.EnabledAt != nil {
+		mfaToken, err := generateMFAToken(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		return &dto.LoginResponse{MFARequired: true, MFAToken: mfaToken}, nil
 	}
 
-	claims := &Claims{
-		User: data,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Unix(expirationTime, 0)),
-		},
+	return u.completeLogin(ctx, user, req.IPAddress)
+}
+
+// findByLoginIdentifier resolves the identifier given on login. Anything with
+// an @ is an email address, since usernames may not contain one; otherwise the
+// username is tried first and then, if enabled, the phone number.
+func (u *UserService) findByLoginIdentifier(ctx context.Context, identifier string) (*models.User, error) {
+	if strings.Contains(identifier, "@") {
+		return u.repository.GetUser().FindByEmail(ctx, identifier)
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(config.Config.JwtSecretKey))
+	user, err := u.repository.GetUser().FindByUsername(ctx, identifier)
+	if err == nil || !errors.Is(err, errConstant.ErrUserNotFound) || !config.Config.AllowPhoneLogin {
+		return user, err
+	}
+	return u.repository.GetUser().FindByPhoneNumber(ctx, identifier)
+}
+
+// completeLogin issues the access token and a new refresh token family for a
+// user whose credentials were fully verified. Failed attempts are cleared only
+// here, so a correct password alone does not reset the count of wrong second
+// factors.
+func (u *UserService) completeLogin(ctx context.Context, user *models.User, ipAddress string) (*dto.LoginResponse, error) {
+	err := u.resetLoginFailures(ctx, user.UserName)
+	if err != nil {
+		return nil, err
+	}
+
+	data := toUserResponse(user)
+	tokenString, err := generateAccessToken(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, _, err := u.issueRefreshToken(ctx, user.ID, uuid.New())
 	if err != nil {
-		fmt.Println("[ERROR] Gagal generate token:", err)
 		return nil, err
 	}
-	fmt.Println("[INFO] Token JWT berhasil dibuat")
-	fmt.Println("[INFO] Token JWT:", tokenString)
 
 	response := &dto.LoginResponse{
-		User:  *data,
-		Token: tokenString,
+		User:         *data,
+		Token:        tokenString,
+		RefreshToken: refreshToken,
 	}
 
+	u.recordLoginAudit(ctx, user, constants.AuditOutcomeSuccess, "", ipAddress)
 	return response, nil
 }
 
@@ -116,8 +202,13 @@ func (u *UserService) isEmailExist(ctx context.Context, username string) bool {
 	return false
 }
 
-func (u *UserService) Register(ctx context.Context, req *dto.RegisterRequest) (*dto.RegisterResponse, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+func (u *UserService) Register(ctx context.Context, req *dto.RegisterRequest) (response *dto.RegisterResponse, err error) {
+	defer func() { recordRegistration(err) }()
+
+	req.UserName = util.NormalizeIdentifier(req.UserName)
+	req.Email = util.NormalizeIdentifier(req.Email)
+
+	hashedPassword, err := hashPassword(ctx, req.Password)
 	if err != nil {
 		return nil, err
 	}
@@ -126,7 +217,7 @@ func (u *UserService) Register(ctx context.Context, req *dto.RegisterRequest) (*
 		return nil, errConstant.ErrUsernameExist
 	}
 
-	if u.isUsernameExist(ctx, req.Email) {
+	if u.isEmailExist(ctx, req.Email) {
 		return nil, errConstant.ErrEmailExist
 	}
 
@@ -152,7 +243,12 @@ func (u *UserService) Register(ctx context.Context, req *dto.RegisterRequest) (*
 		return nil, err
 	}
 
-	response := &dto.RegisterResponse{
+	err = u.sendEmailVerification(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	response = &dto.RegisterResponse{
 		User: dto.UserResponse{
 			UUID:        user.UUID,
 			Name:        user.Name,
@@ -167,10 +263,6 @@ func (u *UserService) Register(ctx context.Context, req *dto.RegisterRequest) (*
 }
 
 func (u *UserService) Update(ctx context.Context, request *dto.UpdateRequest, uuid string) (*dto.UserResponse, error) {
-	//Log awal fungsi
-	fmt.Println("✅ [DEBUG-SERVICE] Memulai proses Update user dengan UUID:", uuid)
-	fmt.Printf("✅ [DEBUG-SERVICE] Data yang diterima: %+v\n", request)
-
 	var (
 		password                  string
 		checkUsername, checkEmail *models.User
@@ -180,35 +272,33 @@ func (u *UserService) Update(ctx context.Context, request *dto.UpdateRequest, uu
 		data                      dto.UserResponse
 	)
 
-	//log pencarian user
-	fmt.Println("✅ [DEBUG-SERVICE] Mencari user dengan UUID:", uuid)
+	err = u.authorizeUserUpdate(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Username = util.NormalizeIdentifier(request.Username)
+	request.Email = util.NormalizeIdentifier(request.Email)
+
 	//cari user berdasarkan uuid
 	//jika tidak ada user, return error
 	//jika ada user, lanjutkan
 
 	user, err = u.repository.GetUser().FindByUUID(ctx, uuid)
 	if err != nil {
-		fmt.Println("❌ [ERROR-SERVICE] Gagal menemukan user:", err)
 		return nil, err
 	}
-	fmt.Println("✅ [DEBUG-SERVICE] User ditemukan, melanjutkan proses update")
-	fmt.Printf("✅ [DEBUG-SERVICE] Data user yang ditemukan: %+v\n", user)
 
 	//cek apakah username sudah ada
-	fmt.Println("✅ [DEBUG-SERVICE] Memeriksa apakah username sudah ada", request.Username)
 	isUsernameExist := u.isUsernameExist(ctx, request.Username)
-	fmt.Println("✅ [DEBUG-SERVICE] Username sudah ada:", isUsernameExist)
 	//jika username sudah ada, cek apakah username yang diinputkan sama dengan username yang ada
 	if isUsernameExist && user.UserName != request.Username {
-		fmt.Println("[DEBUG]Mencari user dengan username:", request.Username)
 		checkUsername, err = u.repository.GetUser().FindByUsername(ctx, request.Username)
 		if err != nil {
-			fmt.Println("❌ [ERROR-SERVICE] Error saat cek username", err)
 			return nil, err
 		}
 
 		if checkUsername != nil {
-			fmt.Println(("❌ [ERROR-SERVICE] Username sudah digunakan"))
 			return nil, errConstant.ErrUsernameExist
 		}
 	}
@@ -229,11 +319,11 @@ func (u *UserService) Update(ctx context.Context, request *dto.UpdateRequest, uu
 	}
 
 	if request.Password != nil {
-		if *request.Password != *request.ConfirmPassword {
+		if request.ConfirmPassword == nil || *request.Password != *request.ConfirmPassword {
 			return nil, errConstant.ErrPasswordDoesNotMatch
 		}
 
-		hashedPassword, err = bcrypt.GenerateFromPassword([]byte(*request.Password), bcrypt.DefaultCost)
+		hashedPassword, err = hashPassword(ctx, *request.Password)
 		if err != nil {
 			return nil, err
 		}
@@ -251,6 +341,27 @@ func (u *UserService) Update(ctx context.Context, request *dto.UpdateRequest, uu
 		return nil, err
 	}
 
+	if request.Password != nil {
+		err = u.revokeAllSessions(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if user.Email != request.Email {
+		err = u.repository.GetUser().UpdateEmailVerifiedAt(ctx, user.ID, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		user.Email = request.Email
+		user.Name = request.Name
+		err = u.sendEmailVerification(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	data = dto.UserResponse{
 		UUID:        userResult.UUID,
 		Name:        userResult.Name,
@@ -262,15 +373,45 @@ func (u *UserService) Update(ctx context.Context, request *dto.UpdateRequest, uu
 	return &data, nil
 }
 
+// authorizeUserUpdate only lets users update their own account unless they are
+// an admin. Denied attempts are written to the audit log.
+func (u *UserService) authorizeUserUpdate(ctx context.Context, targetUUID string) error {
+	userLogin, ok := ctx.Value(constants.UserLogin).(*dto.UserResponse)
+	if !ok || userLogin == nil {
+		return errConstant.ErrUnauthorized
+	}
+
+	target, err := uuid.Parse(targetUUID)
+	if err == nil && target == userLogin.UUID {
+		return nil
+	}
+	if strings.EqualFold(userLogin.Role, constants.RoleAdmin) {
+		return nil
+	}
+
+	audit := &models.AuditLog{
+		ActorUUID: &userLogin.UUID,
+		Action:    constants.AuditActionUserUpdate,
+		Outcome:   constants.AuditOutcomeDenied,
+		Reason:    "caller is neither the account owner nor an admin",
+	}
+	if err == nil {
+		audit.TargetUUID = &target
+	}
+	err = u.repository.GetAudit().Create(ctx, audit)
+	if err != nil {
+		logger.FromContext(ctx).Errorf("failed to record denied update of user %s by %s: %v", targetUUID, userLogin.UUID, err)
+	}
+
+	return errConstant.ErrForbidden
+}
+
 func (u *UserService) GetUserLogin(ctx context.Context) (*dto.UserResponse, error) {
-	fmt.Println("🛡️ [DEBUG-SERVICE] Memulai proses GetUserLogin")
-	fmt.Println("🛡️ [DEBUG-SERVICE] Mengambil data user dari context")
 	var (
 		userLogin = ctx.Value(constants.UserLogin).(*dto.UserResponse)
 		data      dto.UserResponse
 	)
 
-	fmt.Println("🛡️ [DEBUG-SERVICE] Data user login:", userLogin)
 	data = dto.UserResponse{
 		UUID:        userLogin.UUID,
 		Name:        userLogin.Name,
@@ -279,7 +420,6 @@ func (u *UserService) GetUserLogin(ctx context.Context) (*dto.UserResponse, erro
 		PhoneNumber: userLogin.PhoneNumber,
 		Role:        userLogin.Role,
 	}
-	fmt.Println("🛡️ [DEBUG-SERVICE] Mengembalikan data user login ke controller")
 
 	return &data, nil
 }
@@ -287,12 +427,9 @@ func (u *UserService) GetUserLogin(ctx context.Context) (*dto.UserResponse, erro
 func (u *UserService) GetUserByUUID(ctx context.Context, uuid string) (*dto.UserResponse, error) {
 	user, err := u.repository.GetUser().FindByUUID(ctx, uuid)
 	if err != nil {
-		fmt.Println("❌ [ERROR-SERVICE] Gagal menemukan user:", err)
 		return nil, err
 	}
 
-	fmt.Println("✅ [INFO-SERVICE] User ditemukan, mengembalikan data user")
-
 	data := dto.UserResponse{
 		UUID:        user.UUID,
 		Name:        user.Name,
@@ -301,7 +438,5 @@ func (u *UserService) GetUserByUUID(ctx context.Context, uuid string) (*dto.User
 		PhoneNumber: user.PhoneNumber,
 		Role:        strings.ToLower(user.Role.Code),
 	}
-	fmt.Println("✅ [INFO-SERVICE] Data user:", data)
-	fmt.Println("✅ [INFO-SERVICE] Mengembalikan data user ke controller")
 	return &data, nil
 }
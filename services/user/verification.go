@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"user-service/clients/notifier"
+	"user-service/config"
+	"user-service/constants"
+	errConstant "user-service/constants/error"
+	"user-service/domain/dto"
+	"user-service/domain/models"
+)
+
+const (
+	defaultEmailVerificationExpiration     = 1440
+	defaultEmailVerificationResendInterval = 60
+)
+
+func (u *UserService) sendEmailVerification(ctx context.Context, user *models.User) error {
+	expiration := config.Config.EmailVerificationExpiration
+	if expiration <= 0 {
+		expiration = defaultEmailVerificationExpiration
+	}
+
+	token, err := u.issueUserToken(ctx, user.ID, constants.UserTokenPurposeEmailVerification, time.Duration(expiration)*time.Minute)
+	if err != nil {
+		return err
+	}
+
+	message := &notifier.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nPlease confirm your email address with the link below. It expires in %d minutes.\n\n%s",
+			user.Name, expiration, buildLink(config.Config.EmailVerificationURL, token),
+		),
+	}
+	u.sendAsync(ctx, "email verification", func(ctx context.Context) error {
+		return u.notifier.Send(ctx, message)
+	})
+	return nil
+}
+
+func (u *UserService) VerifyEmail(ctx context.Context, req *dto.VerifyEmailRequest) error {
+	token, err := u.consumeUserToken(ctx, constants.UserTokenPurposeEmailVerification, req.Token)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	return u.repository.GetUser().UpdateEmailVerifiedAt(ctx, token.UserID, &now)
+}
+
+// ResendEmailVerification sends a new verification link to an unverified
+// account. Requests for unknown or verified addresses, and requests made within
+// the resend interval, succeed without sending anything.
+func (u *UserService) ResendEmailVerification(ctx context.Context, req *dto.ResendEmailVerificationRequest) error {
+	user, err := u.repository.GetUser().FindByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, errConstant.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+	if user.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	interval := config.Config.EmailVerificationResendInterval
+	if interval <= 0 {
+		interval = defaultEmailVerificationResendInterval
+	}
+
+	latest, err := u.repository.GetUserToken().FindLatest(ctx, user.ID, constants.UserTokenPurposeEmailVerification)
+	if err != nil {
+		return err
+	}
+	if latest != nil && latest.CreatedAt != nil && time.Since(*latest.CreatedAt) < time.Duration(interval)*time.Second {
+		return nil
+	}
+
+	return u.sendEmailVerification(ctx, user)
+}